@@ -15,6 +15,7 @@ type Account struct {
 	Institution  string
 	LoginId      string `json:"login_id"`
 	ContactEmail string `json:"contact_email"`
+	IsStaff      bool   `json:"is_staff"` //是否为系统管理员
 }
 
 //自动添加Token后执行请求
@@ -0,0 +1,138 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//动态类型
+const (
+	ActivityCreate = "create"
+	ActivityDelete = "delete"
+	ActivityRename = "rename"
+	ActivityMove   = "move"
+	ActivityEdit   = "edit"
+)
+
+//用户动态
+type Activity struct {
+	OpType      string    `json:"op_type"`  //ActivityCreate等
+	ObjType     string    `json:"obj_type"` //file、dir或repo
+	RepoId      string    `json:"repo_id"`
+	RepoName    string    `json:"repo_name"`
+	CommitId    string    `json:"commit_id"`
+	Path        string    `json:"path"`
+	Name        string    `json:"name"`
+	OldPath     string    `json:"old_path"` //仅rename和move有效
+	OldName     string    `json:"old_name"` //仅rename有效
+	AuthorEmail string    `json:"author_email"`
+	AuthorName  string    `json:"author_name"`
+	Time        time.Time `json:"time"`
+
+	Commit *LibraryCommit `json:"-"` //仅Library.Events有效
+}
+
+//获取当前用户可见的动态，page从1开始
+func (cli *Client) Activities(page int) ([]*Activity, error) {
+	q := url.Values{"page": {strconv.Itoa(page)}}
+	resp, err := cli.apiGET("/activities/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		Events []*Activity
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return respInfo.Events, nil
+}
+
+//将提交中的变更转换为动态
+func (commit *LibraryCommit) Activities(changes *LibraryCommitChanges) []*Activity {
+	var activities []*Activity
+
+	add := func(opType, objType, path, oldPath string) {
+		path = "/" + strings.Trim(path, "/")
+		activity := &Activity{
+			OpType:      opType,
+			ObjType:     objType,
+			RepoId:      commit.RepoId,
+			CommitId:    commit.Id,
+			Path:        path,
+			Name:        filepath.Base(path),
+			AuthorEmail: commit.Creator,
+			AuthorName:  commit.CreatorName,
+			Time:        commit.Time(),
+			Commit:      commit,
+		}
+
+		if oldPath != "" {
+			activity.OldPath = "/" + strings.Trim(oldPath, "/")
+			activity.OldName = filepath.Base(activity.OldPath)
+		}
+
+		if commit.library != nil {
+			activity.RepoName = commit.library.Name
+		}
+
+		activities = append(activities, activity)
+	}
+
+	for _, p := range changes.AddedFiles {
+		add(ActivityCreate, "file", p, "")
+	}
+	for _, p := range changes.AddedDirs {
+		add(ActivityCreate, "dir", p, "")
+	}
+	for _, p := range changes.ModifiedFiles {
+		add(ActivityEdit, "file", p, "")
+	}
+	for _, p := range changes.DeletedFiles {
+		add(ActivityDelete, "file", p, "")
+	}
+	for _, p := range changes.DeletedDirs {
+		add(ActivityDelete, "dir", p, "")
+	}
+	for i := 0; i+1 < len(changes.RenamedFiles); i += 2 {
+		oldPath, newPath := changes.RenamedFiles[i], changes.RenamedFiles[i+1]
+
+		//同一目录下为重命名，否则为移动
+		opType := ActivityMove
+		if filepath.Dir("/"+strings.Trim(oldPath, "/")) == filepath.Dir("/"+strings.Trim(newPath, "/")) {
+			opType = ActivityRename
+		}
+		add(opType, "file", newPath, oldPath)
+	}
+
+	return activities
+}
+
+//获取资料库指定页提交中的动态，page从1开始
+//    基于提交历史及每个提交的变更生成，按时间由新到旧排列
+func (lib *Library) Events(page, perPage int) ([]*Activity, error) {
+	commits, _, err := lib.HistoryPage(page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []*Activity
+	for _, commit := range commits {
+		changes, err := commit.Changes()
+		if err != nil {
+			return nil, fmt.Errorf("获取提交%s的变更失败: %s", commit.Id, err)
+		}
+
+		activities = append(activities, commit.Activities(changes)...)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,37 @@
+package seafile
+
+import (
+	"testing"
+)
+
+func TestCommitActivities(t *testing.T) {
+	commit := &LibraryCommit{Id: "c1", RepoId: "r1", Creator: "a@example.com", Ctime: 1600000000}
+	changes := &LibraryCommitChanges{
+		AddedFiles:    []string{"a.txt"},
+		ModifiedFiles: []string{"dir/b.txt"},
+		DeletedDirs:   []string{"old"},
+		RenamedFiles:  []string{"c.txt", "d.txt", "e.txt", "dir/e.txt"},
+	}
+
+	expected := []struct {
+		opType, path, oldPath string
+	}{
+		{ActivityCreate, "/a.txt", ""},
+		{ActivityEdit, "/dir/b.txt", ""},
+		{ActivityDelete, "/old", ""},
+		{ActivityRename, "/d.txt", "/c.txt"},
+		{ActivityMove, "/dir/e.txt", "/e.txt"},
+	}
+
+	activities := commit.Activities(changes)
+	if len(activities) != len(expected) {
+		t.Fatalf("期望%d条动态，实际%d条", len(expected), len(activities))
+	}
+
+	for i, e := range expected {
+		a := activities[i]
+		if a.OpType != e.opType || a.Path != e.path || a.OldPath != e.oldPath {
+			t.Errorf("第%d条动态为%s %s %s，期望%s %s %s", i, a.OpType, a.Path, a.OldPath, e.opType, e.path, e.oldPath)
+		}
+	}
+}
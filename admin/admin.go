@@ -0,0 +1,101 @@
+//Seafile系统管理接口
+//    需要使用系统管理员账号的Token
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-http/seafile"
+)
+
+//当前Token不是系统管理员账号
+var ErrNotStaff = errors.New("当前Token不是系统管理员账号")
+
+//系统管理客户端
+type Admin struct {
+	client *seafile.Client
+}
+
+//基于已认证的客户端创建系统管理客户端
+//    如果客户端的账号不是系统管理员，返回ErrNotStaff
+func New(cli *seafile.Client) (*Admin, error) {
+	info, err := cli.AccountInfo()
+	if err != nil {
+		return nil, fmt.Errorf("获取账户信息失败: %s", err)
+	}
+
+	if !info.IsStaff {
+		return nil, ErrNotStaff
+	}
+
+	return &Admin{client: cli}, nil
+}
+
+//发起管理接口请求，并解析返回值
+//    v为nil时不解析返回值，任何2xx状态码都视为成功
+//    管理员权限已在New中检查，403等错误原样返回服务器的信息
+func (adm *Admin) request(method, uri string, form url.Values, v interface{}) error {
+	var header http.Header
+	var body io.Reader
+	if form != nil {
+		header = http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+		body = bytes.NewBufferString(form.Encode())
+	}
+
+	resp, err := adm.client.RequestApi("/api/v2.1/admin", method, uri, header, body)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	//创建时服务器可能返回201，删除时可能返回204
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	return nil
+}
+
+//分页参数
+func pageQuery(page, perPage int) string {
+	q := url.Values{}
+	if page > 0 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		q.Set("per_page", strconv.Itoa(perPage))
+	}
+
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+//分页信息
+type pageInfo struct {
+	HasNextPage bool `json:"has_next_page"`
+	CurrentPage int  `json:"current_page"`
+}
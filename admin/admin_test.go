@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-http/seafile"
+)
+
+func TestIterateUsers(t *testing.T) {
+	client := seafile.New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	adm, err := New(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := adm.IterateUsers(10)
+	for it.Next() {
+		t.Logf("%+v", it.User())
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"net/url"
+	"strconv"
+)
+
+//群组
+type Group struct {
+	Id        int
+	Name      string
+	Owner     string
+	CreatedAt string `json:"created_at"`
+}
+
+//群组的资源地址
+func groupUri(groupID int) string {
+	return "/groups/" + strconv.Itoa(groupID) + "/"
+}
+
+//获取指定页的群组列表
+//    返回值中的bool表示是否还有下一页
+func (adm *Admin) ListGroups(page, perPage int) ([]*Group, bool, error) {
+	var respInfo struct {
+		Groups   []*Group
+		PageInfo pageInfo `json:"page_info"`
+	}
+	err := adm.request("GET", "/groups/"+pageQuery(page, perPage), nil, &respInfo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return respInfo.Groups, respInfo.PageInfo.HasNextPage, nil
+}
+
+//创建群组
+func (adm *Admin) CreateGroup(name, owner string) (*Group, error) {
+	d := url.Values{
+		"group_name":  {name},
+		"group_owner": {owner},
+	}
+
+	var group Group
+	err := adm.request("POST", "/groups/", d, &group)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+//将群组转移给其他用户
+func (adm *Admin) TransferGroup(groupID int, newOwner string) error {
+	d := url.Values{"new_owner": {newOwner}}
+	return adm.request("PUT", groupUri(groupID), d, nil)
+}
+
+//删除群组
+func (adm *Admin) DeleteGroup(groupID int) error {
+	return adm.request("DELETE", groupUri(groupID), nil, nil)
+}
+
+//群组列表迭代器
+type GroupIterator struct {
+	adm     *Admin
+	page    int
+	perPage int
+	hasNext bool
+	groups  []*Group
+	current *Group
+	err     error
+}
+
+//创建群组列表迭代器
+func (adm *Admin) IterateGroups(perPage int) *GroupIterator {
+	if perPage <= 0 {
+		perPage = 100
+	}
+	return &GroupIterator{adm: adm, perPage: perPage, hasNext: true}
+}
+
+//移动到下一个群组，没有更多群组或出错时返回false
+func (it *GroupIterator) Next() bool {
+	for len(it.groups) == 0 {
+		if it.err != nil || !it.hasNext {
+			return false
+		}
+
+		it.page++
+		it.groups, it.hasNext, it.err = it.adm.ListGroups(it.page, it.perPage)
+	}
+
+	it.current = it.groups[0]
+	it.groups = it.groups[1:]
+	return true
+}
+
+//当前群组
+func (it *GroupIterator) Group() *Group {
+	return it.current
+}
+
+//迭代过程中发生的错误
+func (it *GroupIterator) Err() error {
+	return it.err
+}
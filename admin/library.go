@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"net/url"
+)
+
+//资料库
+type Library struct {
+	Id         string
+	Name       string
+	Owner      string
+	OwnerName  string `json:"owner_name"`
+	Size       int64
+	FileCount  int64 `json:"file_count"`
+	Encrypted  bool
+	LastModify string `json:"last_modify"`
+}
+
+//获取指定页的资料库列表
+//    返回值中的bool表示是否还有下一页
+func (adm *Admin) ListLibraries(page, perPage int) ([]*Library, bool, error) {
+	var respInfo struct {
+		Repos    []*Library
+		PageInfo pageInfo `json:"page_info"`
+	}
+	err := adm.request("GET", "/libraries/"+pageQuery(page, perPage), nil, &respInfo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return respInfo.Repos, respInfo.PageInfo.HasNextPage, nil
+}
+
+//将资料库转移给其他用户
+func (adm *Admin) TransferLibrary(repoID, newOwner string) error {
+	d := url.Values{"owner": {newOwner}}
+	return adm.request("PUT", "/libraries/"+repoID+"/", d, nil)
+}
+
+//删除资料库
+func (adm *Admin) DeleteLibrary(repoID string) error {
+	return adm.request("DELETE", "/libraries/"+repoID+"/", nil, nil)
+}
+
+//资料库列表迭代器
+type LibraryIterator struct {
+	adm       *Admin
+	page      int
+	perPage   int
+	hasNext   bool
+	libraries []*Library
+	current   *Library
+	err       error
+}
+
+//创建资料库列表迭代器
+func (adm *Admin) IterateLibraries(perPage int) *LibraryIterator {
+	if perPage <= 0 {
+		perPage = 100
+	}
+	return &LibraryIterator{adm: adm, perPage: perPage, hasNext: true}
+}
+
+//移动到下一个资料库，没有更多资料库或出错时返回false
+func (it *LibraryIterator) Next() bool {
+	for len(it.libraries) == 0 {
+		if it.err != nil || !it.hasNext {
+			return false
+		}
+
+		it.page++
+		it.libraries, it.hasNext, it.err = it.adm.ListLibraries(it.page, it.perPage)
+	}
+
+	it.current = it.libraries[0]
+	it.libraries = it.libraries[1:]
+	return true
+}
+
+//当前资料库
+func (it *LibraryIterator) Library() *Library {
+	return it.current
+}
+
+//迭代过程中发生的错误
+func (it *LibraryIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,268 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+//登录日志
+type LoginLog struct {
+	Email        string
+	Name         string
+	ContactEmail string    `json:"contact_email"`
+	LoginIp      string    `json:"login_ip"`
+	LoginTime    time.Time `json:"login_time"`
+	LogSuccess   bool      `json:"log_success"`
+}
+
+//文件访问日志
+type FileAccessLog struct {
+	Email        string
+	Name         string
+	ContactEmail string `json:"contact_email"`
+	Ip           string
+	Device       string
+	EventType    string    `json:"event_type"`
+	RepoId       string    `json:"repo_id"`
+	RepoName     string    `json:"repo_name"`
+	Path         string    `json:"path"`
+	Time         time.Time `json:"time"`
+}
+
+//文件更新日志
+type FileUpdateLog struct {
+	Email         string
+	Name          string
+	ContactEmail  string    `json:"contact_email"`
+	RepoId        string    `json:"repo_id"`
+	RepoName      string    `json:"repo_name"`
+	RepoEncrypted bool      `json:"repo_encrypted"`
+	CommitId      string    `json:"commit_id"`
+	Description   string    `json:"description"`
+	Time          time.Time `json:"time"`
+}
+
+//共享权限变更日志
+type SharePermissionLog struct {
+	FromUserEmail string    `json:"from_user_email"`
+	FromUserName  string    `json:"from_user_name"`
+	ToUserEmail   string    `json:"to_user_email"`
+	ToUserName    string    `json:"to_user_name"`
+	ToGroupId     int       `json:"to_group_id"`
+	ToGroupName   string    `json:"to_group_name"`
+	Etype         string    `json:"etype"`
+	Permission    string    `json:"permission"`
+	RepoId        string    `json:"repo_id"`
+	RepoName      string    `json:"repo_name"`
+	Path          string    `json:"path"`
+	Date          time.Time `json:"date"`
+}
+
+//日志迭代器的公共部分
+//    日志按时间由新到旧返回，早于since的日志会结束迭代，晚于until的日志会被跳过
+type logIterator struct {
+	adm     *Admin
+	uri     string
+	listKey string
+	timeKey string
+	since   time.Time
+	until   time.Time
+
+	page    int
+	perPage int
+	hasNext bool
+	items   []json.RawMessage
+	current json.RawMessage
+	err     error
+}
+
+func (adm *Admin) newLogIterator(uri, listKey, timeKey string, since, until time.Time) logIterator {
+	return logIterator{
+		adm:     adm,
+		uri:     uri,
+		listKey: listKey,
+		timeKey: timeKey,
+		since:   since,
+		until:   until,
+		perPage: 100,
+		hasNext: true,
+	}
+}
+
+//获取下一页日志
+func (it *logIterator) fetch() {
+	it.page++
+
+	var respInfo map[string]json.RawMessage
+	it.err = it.adm.request("GET", it.uri+pageQuery(it.page, it.perPage), nil, &respInfo)
+	if it.err != nil {
+		return
+	}
+
+	it.items = nil
+	it.hasNext = false
+
+	if raw, ok := respInfo[it.listKey]; ok {
+		it.err = json.Unmarshal(raw, &it.items)
+		if it.err != nil {
+			it.err = fmt.Errorf("解析日志列表错误: %s", it.err)
+			return
+		}
+	}
+
+	if raw, ok := respInfo["has_next_page"]; ok {
+		json.Unmarshal(raw, &it.hasNext)
+	}
+}
+
+//移动到下一条日志，v为该条日志要解析到的结构
+func (it *logIterator) next(v interface{}) bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.items) == 0 {
+			if !it.hasNext {
+				return false
+			}
+			it.fetch()
+			continue
+		}
+
+		raw := it.items[0]
+		it.items = it.items[1:]
+
+		var item map[string]json.RawMessage
+		it.err = json.Unmarshal(raw, &item)
+		if it.err != nil {
+			it.err = fmt.Errorf("解析日志错误: %s %s", it.err, string(raw))
+			return false
+		}
+
+		var t time.Time
+		json.Unmarshal(item[it.timeKey], &t)
+
+		if !it.since.IsZero() && !t.IsZero() && t.Before(it.since) {
+			it.items = nil
+			it.hasNext = false
+			return false
+		}
+
+		if !it.until.IsZero() && t.After(it.until) {
+			continue
+		}
+
+		it.err = json.Unmarshal(raw, v)
+		if it.err != nil {
+			it.err = fmt.Errorf("解析日志错误: %s %s", it.err, string(raw))
+			return false
+		}
+
+		it.current = raw
+		return true
+	}
+}
+
+//当前日志的原始JSON内容
+func (it *logIterator) Raw() json.RawMessage {
+	return it.current
+}
+
+//迭代过程中发生的错误
+func (it *logIterator) Err() error {
+	return it.err
+}
+
+//登录日志迭代器
+//    since、until为零值时表示不限制
+type LoginLogIterator struct {
+	logIterator
+	log LoginLog
+}
+
+//创建登录日志迭代器
+func (adm *Admin) IterateLoginLogs(since, until time.Time) *LoginLogIterator {
+	return &LoginLogIterator{logIterator: adm.newLogIterator("/logs/login-logs/", "login_log_list", "login_time", since, until)}
+}
+
+//移动到下一条日志，没有更多日志或出错时返回false
+func (it *LoginLogIterator) Next() bool {
+	it.log = LoginLog{}
+	return it.next(&it.log)
+}
+
+//当前日志
+func (it *LoginLogIterator) Log() LoginLog {
+	return it.log
+}
+
+//文件访问日志迭代器
+//    since、until为零值时表示不限制
+type FileAccessLogIterator struct {
+	logIterator
+	log FileAccessLog
+}
+
+//创建文件访问日志迭代器
+func (adm *Admin) IterateFileAccessLogs(since, until time.Time) *FileAccessLogIterator {
+	return &FileAccessLogIterator{logIterator: adm.newLogIterator("/logs/file-access-logs/", "file_access_log_list", "time", since, until)}
+}
+
+//移动到下一条日志，没有更多日志或出错时返回false
+func (it *FileAccessLogIterator) Next() bool {
+	it.log = FileAccessLog{}
+	return it.next(&it.log)
+}
+
+//当前日志
+func (it *FileAccessLogIterator) Log() FileAccessLog {
+	return it.log
+}
+
+//文件更新日志迭代器
+//    since、until为零值时表示不限制
+type FileUpdateLogIterator struct {
+	logIterator
+	log FileUpdateLog
+}
+
+//创建文件更新日志迭代器
+func (adm *Admin) IterateFileUpdateLogs(since, until time.Time) *FileUpdateLogIterator {
+	return &FileUpdateLogIterator{logIterator: adm.newLogIterator("/logs/file-update-logs/", "file_update_log_list", "time", since, until)}
+}
+
+//移动到下一条日志，没有更多日志或出错时返回false
+func (it *FileUpdateLogIterator) Next() bool {
+	it.log = FileUpdateLog{}
+	return it.next(&it.log)
+}
+
+//当前日志
+func (it *FileUpdateLogIterator) Log() FileUpdateLog {
+	return it.log
+}
+
+//共享权限变更日志迭代器
+//    since、until为零值时表示不限制
+type SharePermissionLogIterator struct {
+	logIterator
+	log SharePermissionLog
+}
+
+//创建共享权限变更日志迭代器
+func (adm *Admin) IterateSharePermissionLogs(since, until time.Time) *SharePermissionLogIterator {
+	return &SharePermissionLogIterator{logIterator: adm.newLogIterator("/logs/share-permission-logs/", "share_permission_log_list", "date", since, until)}
+}
+
+//移动到下一条日志，没有更多日志或出错时返回false
+func (it *SharePermissionLogIterator) Next() bool {
+	it.log = SharePermissionLog{}
+	return it.next(&it.log)
+}
+
+//当前日志
+func (it *SharePermissionLogIterator) Log() SharePermissionLog {
+	return it.log
+}
@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"net/url"
+	"strconv"
+)
+
+//用户
+type User struct {
+	Email        string
+	Name         string
+	ContactEmail string `json:"contact_email"`
+	LoginId      string `json:"login_id"`
+	Role         string
+	Institution  string
+	IsStaff      bool   `json:"is_staff"`
+	IsActive     bool   `json:"is_active"`
+	QuotaUsage   int64  `json:"quota_usage"`
+	QuotaTotal   int64  `json:"quota_total"` //单位为字节，负数表示不限制
+	CreateTime   string `json:"create_time"`
+	LastLogin    string `json:"last_login"`
+}
+
+//用户的资源地址
+func userUri(email string) string {
+	return "/users/" + url.PathEscape(email) + "/"
+}
+
+//获取指定页的用户列表
+//    返回值中的bool表示是否还有下一页
+func (adm *Admin) ListUsers(page, perPage int) ([]*User, bool, error) {
+	var respInfo struct {
+		Data       []*User
+		TotalCount int `json:"total_count"`
+	}
+	err := adm.request("GET", "/users/"+pageQuery(page, perPage), nil, &respInfo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	hasNext := perPage > 0 && page*perPage < respInfo.TotalCount
+
+	return respInfo.Data, hasNext, nil
+}
+
+//获取用户
+func (adm *Admin) GetUser(email string) (*User, error) {
+	var user User
+	err := adm.request("GET", userUri(email), nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+//创建用户
+func (adm *Admin) CreateUser(email, name, password string, isStaff bool) (*User, error) {
+	d := url.Values{
+		"email":    {email},
+		"name":     {name},
+		"password": {password},
+		"is_staff": {strconv.FormatBool(isStaff)},
+	}
+
+	var user User
+	err := adm.request("POST", "/users/", d, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+//设置用户的空间配额，单位为MB
+func (adm *Admin) UpdateUserQuota(email string, quotaMB int64) error {
+	d := url.Values{"quota_total": {strconv.FormatInt(quotaMB, 10)}}
+	return adm.request("PUT", userUri(email), d, nil)
+}
+
+//停用用户
+func (adm *Admin) DeactivateUser(email string) error {
+	return adm.setUserActive(email, false)
+}
+
+//启用用户
+func (adm *Admin) ActivateUser(email string) error {
+	return adm.setUserActive(email, true)
+}
+
+func (adm *Admin) setUserActive(email string, active bool) error {
+	d := url.Values{"is_active": {strconv.FormatBool(active)}}
+	return adm.request("PUT", userUri(email), d, nil)
+}
+
+//删除用户
+func (adm *Admin) DeleteUser(email string) error {
+	return adm.request("DELETE", userUri(email), nil, nil)
+}
+
+//用户列表迭代器
+//  用法:
+//    it := adm.IterateUsers(100)
+//    for it.Next() {
+//        user := it.User()
+//    }
+//    if err := it.Err(); err != nil {
+//    }
+type UserIterator struct {
+	adm     *Admin
+	page    int
+	perPage int
+	hasNext bool
+	users   []*User
+	current *User
+	err     error
+}
+
+//创建用户列表迭代器
+func (adm *Admin) IterateUsers(perPage int) *UserIterator {
+	if perPage <= 0 {
+		perPage = 100
+	}
+	return &UserIterator{adm: adm, perPage: perPage, hasNext: true}
+}
+
+//移动到下一个用户，没有更多用户或出错时返回false
+func (it *UserIterator) Next() bool {
+	for len(it.users) == 0 {
+		if it.err != nil || !it.hasNext {
+			return false
+		}
+
+		it.page++
+		it.users, it.hasNext, it.err = it.adm.ListUsers(it.page, it.perPage)
+	}
+
+	it.current = it.users[0]
+	it.users = it.users[1:]
+	return true
+}
+
+//当前用户
+func (it *UserIterator) User() *User {
+	return it.current
+}
+
+//迭代过程中发生的错误
+func (it *UserIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,86 @@
+package seafile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//将文件夹恢复到指定提交时的版本
+func (repo *Repo) revertDir(path, commitID string) error {
+	q := url.Values{"p": {path}}
+	d := url.Values{
+		"operation": {"revert"},
+		"commit_id": {commitID},
+	}
+	resp, err := repo.client.apiPOSTForm(repo.Uri()+"/dir/?"+q.Encode(), d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	b, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("[%s] %s", resp.Status, string(b))
+}
+
+//将文件夹恢复到指定提交时的版本
+//    返回恢复后的文件夹及其内容
+func (dir *Dir) Restore(commitID string) (*Dir, []DirEntry, error) {
+	err := dir.repo.revertDir(dir.Path(), commitID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	restored, err := dir.repo.GetDir(dir.Path())
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取恢复后的文件夹失败: %s", err)
+	}
+
+	entries, err := restored.GetEntries()
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取恢复后的文件夹内容失败: %s", err)
+	}
+
+	return restored, entries, nil
+}
+
+//获取修改过该文件夹（含文件夹本身及其内容）的提交，按时间由新到旧排列
+//    服务器不支持按路径过滤提交历史，需要逐个获取提交的变更，每个提交一次请求
+//    since为零值时不限制时间，limit为最多返回的提交数量，为0时不限制
+func (dir *Dir) History(since time.Time, limit int) ([]*LibraryCommit, error) {
+	dirPath := strings.Trim(dir.Path(), "/")
+
+	var commits []*LibraryCommit
+
+	it := dir.repo.Library().IterateHistory(100)
+	it.Since = since
+	for (limit <= 0 || len(commits) < limit) && it.Next() {
+		commit := it.Commit()
+
+		changes, err := commit.Changes()
+		if err != nil {
+			return nil, fmt.Errorf("获取提交%s的变更失败: %s", commit.Id, err)
+		}
+
+		for _, p := range changes.Paths() {
+			p = strings.Trim(p, "/")
+			if dirPath == "" || p == dirPath || strings.HasPrefix(p, dirPath+"/") {
+				commits = append(commits, commit)
+				break
+			}
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,112 @@
+package seafile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+//文件评论
+type Comment struct {
+	Id         int
+	RepoId     string `json:"repo_id"`
+	ParentPath string `json:"parent_path"`
+	ItemName   string `json:"item_name"`
+	Comment    string
+	Detail     string //评论的附加信息，如文档中的位置
+	Resolved   bool
+	UserEmail  string `json:"user_email"`
+	UserName   string `json:"user_name"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+//执行文件评论相关请求
+//    q为额外的查询参数，d为表单内容，v为nil时不解析返回值
+func (file *File) commentRequest(method, uri string, q, d url.Values, v interface{}) error {
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Set("p", file.Path())
+
+	var header http.Header
+	var body io.Reader
+	if d != nil {
+		header = http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+		body = bytes.NewBufferString(d.Encode())
+	}
+
+	uri = file.repo.Uri() + "/file/comments/" + uri + "?" + q.Encode()
+	resp, err := file.repo.client.doRequest(method, uri, header, body)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if v == nil {
+		return checkResponse(resp)
+	}
+
+	return decodeResponse(resp, v)
+}
+
+//获取文件的所有评论
+func (file *File) Comments() ([]*Comment, error) {
+	var comments []*Comment
+
+	perPage := 100
+	for page := 1; ; page++ {
+		q := url.Values{
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(perPage)},
+		}
+
+		var respInfo struct {
+			Comments   []*Comment
+			TotalCount int `json:"total_count"`
+		}
+		err := file.commentRequest("GET", "", q, nil, &respInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, respInfo.Comments...)
+
+		if len(respInfo.Comments) < perPage || len(comments) >= respInfo.TotalCount {
+			break
+		}
+	}
+
+	return comments, nil
+}
+
+//添加评论
+//    detail为评论的附加信息，可以为空
+func (file *File) AddComment(text, detail string) (*Comment, error) {
+	d := url.Values{"comment": {text}}
+	if detail != "" {
+		d.Set("detail", detail)
+	}
+
+	var comment Comment
+	err := file.commentRequest("POST", "", nil, d, &comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+//将评论标记为已解决或未解决
+func (file *File) ResolveComment(id int, resolved bool) error {
+	d := url.Values{"resolved": {strconv.FormatBool(resolved)}}
+	return file.commentRequest("PUT", strconv.Itoa(id)+"/", nil, d, nil)
+}
+
+//删除评论
+func (file *File) DeleteComment(id int) error {
+	return file.commentRequest("DELETE", strconv.Itoa(id)+"/", nil, nil, nil)
+}
@@ -0,0 +1,149 @@
+package seafile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+//文本比较时允许的最大文件大小
+const MaxDiffFileSize = 1024 * 1024
+
+//文件的历史版本
+type FileRevision struct {
+	CommitId          string    `json:"commit_id"`
+	RevFileId         string    `json:"rev_file_id"`
+	Size              int64     `json:"size"`
+	Path              string    `json:"path"`
+	Description       string    `json:"description"`
+	CreatorName       string    `json:"creator_name"`
+	CreatorEmail      string    `json:"creator_email"`
+	RevRenamedOldPath string    `json:"rev_renamed_old_path"`
+	Ctime             time.Time `json:"ctime"`
+}
+
+//获取文件的所有历史版本，按时间由新到旧排列
+func (file *File) Revisions() ([]FileRevision, error) {
+	var revisions []FileRevision
+
+	perPage := 100
+	for page := 1; ; page++ {
+		q := url.Values{
+			"path":     {file.Path()},
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(perPage)},
+		}
+
+		resp, err := file.repo.client.apiGET(file.repo.Uri() + "/file/new_history/?" + q.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("请求错误:%s", err)
+		}
+
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("读取错误:%s %s", resp.Status, err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+		}
+
+		var respInfo struct {
+			Data       []FileRevision
+			TotalCount int `json:"total_count"`
+		}
+		err = json.Unmarshal(b, &respInfo)
+		if err != nil {
+			return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
+		}
+
+		revisions = append(revisions, respInfo.Data...)
+
+		if len(respInfo.Data) < perPage || len(revisions) >= respInfo.TotalCount {
+			break
+		}
+	}
+
+	return revisions, nil
+}
+
+//将文件恢复到指定提交时的版本
+func (repo *Repo) revertFile(path, commitID string) error {
+	q := url.Values{"p": {path}}
+	d := url.Values{
+		"operation": {"revert"},
+		"commit_id": {commitID},
+	}
+	resp, err := repo.client.apiPOSTForm(repo.Uri()+"/file/?"+q.Encode(), d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	b, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("[%s] %s", resp.Status, string(b))
+}
+
+//将文件恢复到指定提交时的版本
+//    恢复操作会产生一个新的版本，不会丢失之后的历史
+func (file *File) RestoreRevision(commitID string) error {
+	return file.repo.revertFile(file.Path(), commitID)
+}
+
+//打开文件在指定提交时的版本
+//    返回的内容需要调用者关闭
+func (file *File) OpenRevision(commitID string) (io.ReadCloser, error) {
+	return file.repo.client.openFileRevision(file.RepoId, file.Path(), commitID)
+}
+
+//读取文件在指定提交时的文本内容
+func (file *File) readRevisionText(commitID string) (string, error) {
+	r, err := file.OpenRevision(commitID)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(io.LimitReader(r, MaxDiffFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("读取文件内容错误: %s", err)
+	}
+
+	if len(b) > MaxDiffFileSize {
+		return "", fmt.Errorf("文件超过%d字节，不支持比较", MaxDiffFileSize)
+	}
+
+	if !utf8.Valid(b) || bytes.IndexByte(b, 0) >= 0 {
+		return "", fmt.Errorf("文件不是文本文件，不支持比较")
+	}
+
+	return string(b), nil
+}
+
+//比较文件两个版本的文本内容
+//    仅适用于小于MaxDiffFileSize的文本文件，返回格式及差异规模的限制见DiffText
+func (file *File) DiffRevisions(oldCommitID, newCommitID string) (string, error) {
+	oldText, err := file.readRevisionText(oldCommitID)
+	if err != nil {
+		return "", fmt.Errorf("读取版本%s错误: %s", oldCommitID, err)
+	}
+
+	newText, err := file.readRevisionText(newCommitID)
+	if err != nil {
+		return "", fmt.Errorf("读取版本%s错误: %s", newCommitID, err)
+	}
+
+	return DiffText(oldText, newText)
+}
@@ -0,0 +1,65 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+)
+
+//执行文件锁相关操作
+func (file *File) lockOperation(operation string) error {
+	q := url.Values{"p": {file.Path()}}
+	d := url.Values{"operation": {operation}}
+
+	resp, err := file.repo.client.apiPUTForm(file.repo.Uri()+"/file/?"+q.Encode(), d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//锁定文件
+func (file *File) Lock() error {
+	err := file.lockOperation("lock")
+	if err != nil {
+		return err
+	}
+
+	file.IsLocked = true
+	return nil
+}
+
+//解锁文件
+func (file *File) Unlock() error {
+	err := file.lockOperation("unlock")
+	if err != nil {
+		return err
+	}
+
+	file.IsLocked = false
+	return nil
+}
+
+//刷新文件锁的过期时间
+//    需要Seafile 9.0及以上版本
+func (file *File) RefreshLock() error {
+	return file.lockOperation("refresh-lock")
+}
+
+//锁定文件后执行f，无论f是否成功，返回前都会解锁文件
+func (file *File) WithLock(f func() error) (err error) {
+	err = file.Lock()
+	if err != nil {
+		return fmt.Errorf("锁定文件失败: %s", err)
+	}
+
+	defer func() {
+		unlockErr := file.Unlock()
+		if unlockErr != nil && err == nil {
+			err = fmt.Errorf("解锁文件失败: %s", unlockErr)
+		}
+	}()
+
+	return f()
+}
@@ -0,0 +1,179 @@
+package seafile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+)
+
+//目标位置已存在同名文件时的处理策略
+type ConflictPolicy int
+
+const (
+	ConflictFail       ConflictPolicy = iota //返回错误
+	ConflictReplace                          //操作成功后删除已有文件，并将新文件改为目标文件名
+	ConflictAutoRename                       //由服务器自动重命名新文件
+)
+
+//检查文件是否存在
+//    只有服务器返回404时才认为文件不存在，其他错误原样返回
+func (repo *Repo) fileExists(path string) (bool, error) {
+	q := url.Values{"p": {path}}
+	resp, err := repo.client.apiGET(repo.Uri() + "/file/?" + q.Encode())
+	if err != nil {
+		return false, fmt.Errorf("请求文件信息失败: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+
+	b, _ := ioutil.ReadAll(resp.Body)
+	return false, fmt.Errorf("[%s] %s", resp.Status, string(b))
+}
+
+//执行文件操作，返回操作后的文件
+func (file *File) operate(d url.Values) (*File, error) {
+	q := url.Values{"p": {file.Path()}}
+	resp, err := file.repo.client.apiPOSTForm(file.repo.Uri()+"/file/?"+q.Encode(), d)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("[%s]%s", resp.Status, string(b))
+	}
+
+	var result File
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("解析文件信息失败: %s, %s", resp.Status, err)
+	}
+
+	return &result, nil
+}
+
+//重命名文件，不处理冲突
+func (file *File) rename(newName string) (*File, error) {
+	d := url.Values{
+		"operation": {"rename"},
+		"newname":   {newName},
+	}
+	result, err := file.operate(d)
+	if err != nil {
+		return nil, err
+	}
+
+	result.repo = file.repo
+	if result.ParentDir == "" {
+		result.ParentDir = file.ParentDir
+	}
+
+	return result, nil
+}
+
+//按照冲突策略执行文件操作，操作结果为repo中dir目录下的name
+//    op执行实际的操作，目标已存在时由服务器自动重命名
+func (file *File) withConflictPolicy(repo *Repo, dir, name string, policy ConflictPolicy, op func() (*File, error)) (*File, error) {
+	if policy == ConflictAutoRename {
+		return op()
+	}
+
+	dst := filepath.Join(dir, name)
+
+	//目标就是文件本身
+	if repo.Id == file.repo.Id && dst == file.Path() {
+		if policy == ConflictFail {
+			return nil, fmt.Errorf("目标文件已存在: %s", dst)
+		}
+		return file, nil
+	}
+
+	exists, err := repo.fileExists(dst)
+	if err != nil {
+		return nil, fmt.Errorf("检查目标文件失败: %s", err)
+	}
+
+	if exists && policy == ConflictFail {
+		return nil, fmt.Errorf("目标文件已存在: %s", dst)
+	}
+
+	//先执行操作（服务器会自动重命名），成功后再删除已有文件并改为目标文件名
+	//这样操作失败时不会丢失已有文件
+	result, err := op()
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Name == name {
+		return result, nil
+	}
+
+	//检查时目标不存在但仍被自动重命名，说明目标是同名文件夹或检查后新出现的文件
+	if policy == ConflictFail {
+		return result, fmt.Errorf("目标已存在，新文件被重命名为%s", result.Path())
+	}
+
+	old := &File{Name: name, ParentDir: dir, RepoId: repo.Id, repo: repo}
+	err = old.Delete()
+	if err != nil {
+		return result, fmt.Errorf("删除已有文件失败，新文件保留为%s: %s", result.Path(), err)
+	}
+
+	renamed, err := result.rename(name)
+	if err != nil {
+		return result, fmt.Errorf("已删除原文件，但新文件%s改名失败: %s", result.Path(), err)
+	}
+
+	return renamed, nil
+}
+
+//重命名文件，返回重命名后的文件
+func (file *File) Rename(newName string, policy ConflictPolicy) (*File, error) {
+	return file.withConflictPolicy(file.repo, file.ParentDir, newName, policy, func() (*File, error) {
+		return file.rename(newName)
+	})
+}
+
+//复制或移动文件到指定资料库的指定目录
+func (file *File) transfer(operation string, repo *Repo, dir string, policy ConflictPolicy) (*File, error) {
+	return file.withConflictPolicy(repo, dir, file.Name, policy, func() (*File, error) {
+		d := url.Values{
+			"operation": {operation},
+			"dst_repo":  {repo.Id},
+			"dst_dir":   {dir},
+		}
+		result, err := file.operate(d)
+		if err != nil {
+			return nil, err
+		}
+
+		result.repo = repo
+		if result.ParentDir == "" {
+			result.ParentDir = dir
+		}
+
+		return result, nil
+	})
+}
+
+//复制文件到指定资料库的指定目录，返回复制得到的新文件
+//    目标目录必须存在
+func (file *File) CopyTo(repo *Repo, dir string, policy ConflictPolicy) (*File, error) {
+	return file.transfer("copy", repo, dir, policy)
+}
+
+//移动文件到指定资料库的指定目录，返回移动后的文件
+//    目标目录必须存在
+func (file *File) MoveTo(repo *Repo, dir string, policy ConflictPolicy) (*File, error) {
+	return file.transfer("move", repo, dir, policy)
+}
@@ -0,0 +1,185 @@
+package seafile
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//模拟服务器上的文件操作，目标已存在时自动重命名
+type fakeFileServer struct {
+	files map[string]string //路径 => 内容
+	dirs  map[string]bool
+	ops   []string
+}
+
+func (s *fakeFileServer) taken(path string) bool {
+	_, found := s.files[path]
+	return found || s.dirs[path]
+}
+
+func (s *fakeFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v2.1/repos/repo/file/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	p := r.URL.Query().Get("p")
+	s.ops = append(s.ops, r.Method+" "+p)
+
+	switch r.Method {
+	case "GET":
+		if _, found := s.files[p]; !found {
+			http.Error(w, `{"error_msg": "File not found"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"obj_name": filepath.Base(p), "parent_dir": filepath.Dir(p)})
+	case "DELETE":
+		if _, found := s.files[p]; !found {
+			http.Error(w, `{"error_msg": "File not found"}`, http.StatusNotFound)
+			return
+		}
+		delete(s.files, p)
+		w.Write([]byte(`{"success": true}`))
+	case "POST":
+		r.ParseForm()
+		content := s.files[p]
+
+		dir := filepath.Dir(p)
+		name := r.PostForm.Get("newname")
+		if name == "" {
+			dir = r.PostForm.Get("dst_dir")
+			name = filepath.Base(p)
+		}
+
+		dst := filepath.Join(dir, name)
+		for i := 1; s.taken(dst); i++ {
+			ext := filepath.Ext(name)
+			dst = filepath.Join(dir, strings.TrimSuffix(name, ext)+" ("+string(rune('0'+i))+")"+ext)
+		}
+
+		if r.PostForm.Get("operation") != "copy" {
+			delete(s.files, p)
+		}
+		s.files[dst] = content
+
+		json.NewEncoder(w).Encode(map[string]string{"obj_name": filepath.Base(dst), "parent_dir": dir})
+	}
+}
+
+func newFakeFileServer(files map[string]string, dirs ...string) (*fakeFileServer, *Repo, func()) {
+	fs := &fakeFileServer{files: files, dirs: map[string]bool{}}
+	for _, dir := range dirs {
+		fs.dirs[dir] = true
+	}
+
+	server := httptest.NewServer(fs)
+	repo := &Repo{Id: "repo", client: New(server.URL, "token")}
+	return fs, repo, server.Close
+}
+
+func TestCopyToConflictFail(t *testing.T) {
+	fs, repo, done := newFakeFileServer(map[string]string{"/a.txt": "new", "/b/a.txt": "old"})
+	defer done()
+
+	file := &File{Name: "a.txt", ParentDir: "/", repo: repo}
+	_, err := file.CopyTo(repo, "/b", ConflictFail)
+	if err == nil {
+		t.Fatal("目标已存在时应返回错误")
+	}
+
+	if fs.files["/b/a.txt"] != "old" || len(fs.files) != 2 {
+		t.Fatalf("目标已存在时不应执行操作: %v", fs.files)
+	}
+}
+
+func TestCopyToConflictFailDirectory(t *testing.T) {
+	_, repo, done := newFakeFileServer(map[string]string{"/a.txt": "new"}, "/b/a.txt")
+	defer done()
+
+	//同名文件夹不会被文件接口检查到，需要根据操作结果判断
+	file := &File{Name: "a.txt", ParentDir: "/", repo: repo}
+	result, err := file.CopyTo(repo, "/b", ConflictFail)
+	if err == nil {
+		t.Fatal("目标被文件夹占用时应返回错误")
+	}
+
+	if result == nil || result.Name != "a (1).txt" {
+		t.Fatalf("应返回被重命名的文件: %+v", result)
+	}
+}
+
+func TestCopyToConflictReplace(t *testing.T) {
+	fs, repo, done := newFakeFileServer(map[string]string{"/a.txt": "new", "/b/a.txt": "old"})
+	defer done()
+
+	file := &File{Name: "a.txt", ParentDir: "/", repo: repo}
+	result, err := file.CopyTo(repo, "/b", ConflictReplace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Path() != "/b/a.txt" {
+		t.Fatalf("替换后的文件路径错误: %s", result.Path())
+	}
+
+	if fs.files["/b/a.txt"] != "new" || len(fs.files) != 2 {
+		t.Fatalf("替换结果错误: %v", fs.files)
+	}
+
+	//必须先复制成功，再删除已有文件
+	copied, deleted := -1, -1
+	for i, op := range fs.ops {
+		switch op {
+		case "POST /a.txt":
+			copied = i
+		case "DELETE /b/a.txt":
+			deleted = i
+		}
+	}
+	if copied < 0 || deleted < copied {
+		t.Fatalf("操作顺序错误: %v", fs.ops)
+	}
+}
+
+func TestCopyToConflictAutoRename(t *testing.T) {
+	fs, repo, done := newFakeFileServer(map[string]string{"/a.txt": "new", "/b/a.txt": "old"})
+	defer done()
+
+	file := &File{Name: "a.txt", ParentDir: "/", repo: repo}
+	result, err := file.CopyTo(repo, "/b", ConflictAutoRename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Path() != "/b/a (1).txt" {
+		t.Fatalf("自动重命名的文件路径错误: %s", result.Path())
+	}
+
+	if fs.files["/b/a.txt"] != "old" || fs.files["/b/a (1).txt"] != "new" {
+		t.Fatalf("自动重命名不应修改已有文件: %v", fs.files)
+	}
+}
+
+func TestRenameToSelf(t *testing.T) {
+	fs, repo, done := newFakeFileServer(map[string]string{"/a.txt": "a"})
+	defer done()
+
+	file := &File{Name: "a.txt", ParentDir: "/", repo: repo}
+	_, err := file.Rename("a.txt", ConflictFail)
+	if err == nil {
+		t.Fatal("重命名为自身时ConflictFail应返回错误")
+	}
+
+	result, err := file.Rename("a.txt", ConflictReplace)
+	if err != nil || result != file {
+		t.Fatalf("重命名为自身时ConflictReplace应返回原文件: %v %v", result, err)
+	}
+
+	if len(fs.ops) != 0 {
+		t.Fatalf("重命名为自身时不应请求服务器: %v", fs.ops)
+	}
+}
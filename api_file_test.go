@@ -63,3 +63,89 @@ func TestDeleteFile(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestFileRevisions(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	repo, err := client.GetRepoByName("测试")
+	if err != nil {
+		t.Fatalf("获取资料库错误: %s", err)
+	}
+
+	file, err := repo.TouchFile("/testdir1/file1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := file.Revisions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rev := range revisions {
+		t.Logf("%+v", rev)
+	}
+}
+
+func TestFileWithLock(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	repo, err := client.GetRepoByName("测试")
+	if err != nil {
+		t.Fatalf("获取资料库错误: %s", err)
+	}
+
+	file, err := repo.TouchFile("/testdir1/file1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = file.WithLock(func() error {
+		return file.Update([]byte("锁定期间更新"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.IsLocked {
+		t.Fatal("文件未解锁")
+	}
+}
+
+func TestFileComments(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	repo, err := client.GetRepoByName("测试")
+	if err != nil {
+		t.Fatalf("获取资料库错误: %s", err)
+	}
+
+	file, err := repo.TouchFile("/testdir1/file1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comment, err := file.AddComment("测试评论", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = file.ResolveComment(comment.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comments, err := file.Comments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range comments {
+		t.Logf("%+v", c)
+	}
+
+	err = file.DeleteComment(comment.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -112,3 +112,14 @@ func (repo *Repo) FileUploadLink() (string, error) {
 func (repo *Repo) FileUpdateLink() (string, error) {
 	return repo.getFileServerLink("update")
 }
+
+//以v2接口的资料库形式访问
+func (repo *Repo) Library() *Library {
+	return &Library{
+		Id:        repo.Id,
+		Name:      repo.Name,
+		Owner:     repo.OwnerEmail,
+		Encrypted: repo.Encrypted,
+		client:    repo.client,
+	}
+}
@@ -0,0 +1,204 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+//资料库标签
+type Tag struct {
+	Id         int    `json:"repo_tag_id"`
+	RepoId     string `json:"repo_id"`
+	Name       string `json:"tag_name"`
+	Color      string `json:"tag_color"` //如#FF8000
+	FilesCount int    `json:"files_count"`
+
+	repo *Repo `json:"-"`
+}
+
+//文件上的标签
+type FileTag struct {
+	Id        int    `json:"file_tag_id"`
+	RepoTagId int    `json:"repo_tag_id"`
+	Name      string `json:"tag_name"`
+	Color     string `json:"tag_color"`
+}
+
+//带有标签的文件
+type TaggedFile struct {
+	FileTagId     int    `json:"file_tag_id"`
+	ParentPath    string `json:"parent_path"`
+	Filename      string `json:"filename"`
+	Size          int64  `json:"size"`
+	Mtime         int64  `json:"mtime"`
+	ModifierEmail string `json:"modifier_email"`
+	ModifierName  string `json:"modifier_name"`
+}
+
+//获取资料库的所有标签
+func (repo *Repo) ListTags() ([]*Tag, error) {
+	resp, err := repo.client.apiGET(repo.Uri() + "/repo-tags/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		RepoTags []*Tag `json:"repo_tags"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range respInfo.RepoTags {
+		tag.repo = repo
+	}
+
+	return respInfo.RepoTags, nil
+}
+
+//创建资料库标签
+func (repo *Repo) CreateTag(name, color string) (*Tag, error) {
+	d := url.Values{"name": {name}, "color": {color}}
+	resp, err := repo.client.apiPOSTForm(repo.Uri()+"/repo-tags/", d)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		RepoTag *Tag `json:"repo_tag"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if respInfo.RepoTag == nil {
+		return nil, fmt.Errorf("返回值中没有标签信息")
+	}
+
+	respInfo.RepoTag.repo = repo
+
+	return respInfo.RepoTag, nil
+}
+
+//修改标签的名称和颜色
+func (tag *Tag) Update(name, color string) error {
+	d := url.Values{"name": {name}, "color": {color}}
+	resp, err := tag.repo.client.apiPUTForm(tag.repo.Uri()+"/repo-tags/"+strconv.Itoa(tag.Id)+"/", d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	err = checkResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	tag.Name = name
+	tag.Color = color
+
+	return nil
+}
+
+//删除标签，文件上的该标签也会被移除
+func (tag *Tag) Delete() error {
+	resp, err := tag.repo.client.apiDELETE(tag.repo.Uri() + "/repo-tags/" + strconv.Itoa(tag.Id) + "/")
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//获取带有该标签的所有文件
+func (tag *Tag) Files() ([]*TaggedFile, error) {
+	resp, err := tag.repo.client.apiGET(tag.repo.Uri() + "/tagged-files/" + strconv.Itoa(tag.Id) + "/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		TaggedFiles []*TaggedFile `json:"tagged_files"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return respInfo.TaggedFiles, nil
+}
+
+//获取文件上的标签
+func (file *File) Tags() ([]*FileTag, error) {
+	q := url.Values{"file_path": {file.Path()}}
+	resp, err := file.repo.client.apiGET(file.repo.Uri() + "/file-tags/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		FileTags []*FileTag `json:"file_tags"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return respInfo.FileTags, nil
+}
+
+//为文件添加标签
+func (file *File) AddTag(tag *Tag) (*FileTag, error) {
+	d := url.Values{
+		"file_path":   {file.Path()},
+		"repo_tag_id": {strconv.Itoa(tag.Id)},
+	}
+	resp, err := file.repo.client.apiPOSTForm(file.repo.Uri()+"/file-tags/", d)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		FileTag *FileTag `json:"file_tag"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if respInfo.FileTag == nil {
+		return nil, fmt.Errorf("返回值中没有标签信息")
+	}
+
+	return respInfo.FileTag, nil
+}
+
+//移除文件上的标签
+func (file *File) RemoveTag(fileTag *FileTag) error {
+	resp, err := file.repo.client.apiDELETE(file.repo.Uri() + "/file-tags/" + strconv.Itoa(fileTag.Id) + "/")
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//获取资料库的所有标签
+func (lib *Library) ListTags() ([]*Tag, error) {
+	return lib.repo().ListTags()
+}
+
+//创建资料库标签
+func (lib *Library) CreateTag(name, color string) (*Tag, error) {
+	return lib.repo().CreateTag(name, color)
+}
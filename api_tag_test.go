@@ -0,0 +1,45 @@
+package seafile
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRepoTags(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	repo, err := client.GetRepoByName("测试")
+	if err != nil {
+		t.Fatalf("获取资料库错误: %s", err)
+	}
+
+	tag, err := repo.CreateTag("待审核", "#FF8000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tag.Delete()
+
+	file, err := repo.TouchFile("/testdir1/file1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileTag, err := file.AddTag(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := tag.Files()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		t.Logf("%+v", f)
+	}
+
+	err = file.RemoveTag(fileTag)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -6,5 +6,5 @@ import (
 )
 
 func (cli *Client) doRequest(method, uri string, header http.Header, body io.Reader) (*http.Response, error) {
-	return cli.requestApi("/api2", method, uri, header, body)
+	return cli.RequestApi("/api2", method, uri, header, body)
 }
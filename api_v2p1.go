@@ -2,13 +2,16 @@ package seafile
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 )
 
 func (cli *Client) apiRequestV2p1(method, uri string, header http.Header, body io.Reader) (*http.Response, error) {
-	return cli.requestApi("/api/v2.1", method, uri, header, body)
+	return cli.RequestApi("/api/v2.1", method, uri, header, body)
 }
 
 func (cli *Client) apiGET(uri string) (*http.Response, error) {
@@ -24,10 +27,63 @@ func (cli *Client) apiPOST(uri string, header http.Header, body io.Reader) (*htt
 	return cli.apiRequestV2p1("POST", uri, header, body)
 }
 
+func (cli *Client) apiRequestJSON(method, uri string, v interface{}) (*http.Response, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	return cli.apiRequestV2p1(method, uri, header, bytes.NewReader(b))
+}
+
+func (cli *Client) apiPOSTJSON(uri string, v interface{}) (*http.Response, error) {
+	return cli.apiRequestJSON("POST", uri, v)
+}
+
 func (cli *Client) apiPUT(uri string, header http.Header, body io.Reader) (*http.Response, error) {
 	return cli.apiRequestV2p1("PUT", uri, header, body)
 }
 
+func (cli *Client) apiPUTForm(uri string, form url.Values) (*http.Response, error) {
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	return cli.apiRequestV2p1("PUT", uri, header, bytes.NewBufferString(form.Encode()))
+}
+
 func (cli *Client) apiDELETE(uri string) (*http.Response, error) {
 	return cli.apiRequestV2p1("DELETE", uri, nil, nil)
 }
+
+//检查状态码并解析JSON格式的返回值
+func decodeResponse(resp *http.Response, v interface{}) error {
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		return fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	return nil
+}
+
+//检查状态码，不解析返回值
+func checkResponse(resp *http.Response) error {
+	if isSuccess(resp.StatusCode) {
+		return nil
+	}
+
+	b, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("[%s] %s", resp.Status, string(b))
+}
+
+//状态码是否为2xx，创建时服务器可能返回201，删除时可能返回204
+func isSuccess(code int) bool {
+	return code >= 200 && code < 300
+}
@@ -0,0 +1,393 @@
+package seafile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//批量复制、移动的请求参数
+type batchItemRequest struct {
+	SrcRepoId    string   `json:"src_repo_id"`
+	SrcParentDir string   `json:"src_parent_dir"`
+	DstRepoId    string   `json:"dst_repo_id"`
+	DstParentDir string   `json:"dst_parent_dir"`
+	SrcDirents   []string `json:"src_dirents"`
+}
+
+//发起批量复制、移动请求
+func (lib *Library) batchItems(api, srcDir string, names []string, dst *Library, dstDir string) ([]byte, error) {
+	req := batchItemRequest{
+		SrcRepoId:    lib.Id,
+		SrcParentDir: srcDir,
+		DstRepoId:    dst.Id,
+		DstParentDir: dstDir,
+		SrcDirents:   names,
+	}
+
+	resp, err := lib.client.apiPOSTJSON("/repos/"+api+"/", req)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	return b, nil
+}
+
+//同步批量复制srcDir下的多个文件或文件夹到另一个资料库的dstDir
+//    请求在全部复制完成后才返回，适合数量较少的情况
+func (lib *Library) BatchCopyItems(srcDir string, names []string, dst *Library, dstDir string) error {
+	_, err := lib.batchItems("sync-batch-copy-item", srcDir, names, dst, dstDir)
+	return err
+}
+
+//同步批量移动srcDir下的多个文件或文件夹到另一个资料库的dstDir
+//    请求在全部移动完成后才返回，适合数量较少的情况
+func (lib *Library) BatchMoveItems(srcDir string, names []string, dst *Library, dstDir string) error {
+	_, err := lib.batchItems("sync-batch-move-item", srcDir, names, dst, dstDir)
+	return err
+}
+
+//发起异步批量复制、移动任务
+func (lib *Library) asyncBatchItems(api, srcDir string, names []string, dst *Library, dstDir string) (*CopyMoveTask, error) {
+	b, err := lib.batchItems(api, srcDir, names, dst, dstDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var respInfo struct {
+		TaskId string `json:"task_id"`
+	}
+	err = json.Unmarshal(b, &respInfo)
+	if err != nil {
+		return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	task := &CopyMoveTask{
+		Id:     respInfo.TaskId,
+		client: lib.client,
+	}
+
+	return task, nil
+}
+
+//异步批量复制srcDir下的多个文件或文件夹到另一个资料库的dstDir
+//    返回的任务可用于查询进度或取消
+func (lib *Library) AsyncBatchCopyItems(srcDir string, names []string, dst *Library, dstDir string) (*CopyMoveTask, error) {
+	return lib.asyncBatchItems("async-batch-copy-item", srcDir, names, dst, dstDir)
+}
+
+//异步批量移动srcDir下的多个文件或文件夹到另一个资料库的dstDir
+//    返回的任务可用于查询进度或取消
+func (lib *Library) AsyncBatchMoveItems(srcDir string, names []string, dst *Library, dstDir string) (*CopyMoveTask, error) {
+	return lib.asyncBatchItems("async-batch-move-item", srcDir, names, dst, dstDir)
+}
+
+//异步复制、移动任务
+type CopyMoveTask struct {
+	Id string
+
+	client *Client
+}
+
+//异步复制、移动任务的进度
+type CopyMoveProgress struct {
+	Done         int //已完成的数量
+	Total        int //总数量
+	Successful   bool
+	Failed       bool
+	Canceled     bool
+	FailedReason string `json:"failed_reason"`
+}
+
+//查询任务进度
+func (task *CopyMoveTask) Progress() (*CopyMoveProgress, error) {
+	q := url.Values{"task_id": {task.Id}}
+	resp, err := task.client.apiGET("/query-copy-move-progress/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var progress CopyMoveProgress
+	err = decodeResponse(resp, &progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &progress, nil
+}
+
+//查询任务进度的默认间隔
+const defaultWaitInterval = time.Second
+
+//每隔interval查询一次进度，直到任务结束
+//    interval小于等于0时使用默认的一秒
+//    timeout为最长等待时间，为0时不限制，此时任务一直未结束会一直等待
+//    任务失败、被取消或等待超时时返回错误，超时不会取消任务
+func (task *CopyMoveTask) Wait(interval, timeout time.Duration) (*CopyMoveProgress, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		progress, err := task.Progress()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case progress.Canceled:
+			return progress, fmt.Errorf("任务已取消")
+		case progress.Failed:
+			return progress, fmt.Errorf("任务失败: %s", progress.FailedReason)
+		case progress.Successful:
+			return progress, nil
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return progress, fmt.Errorf("等待任务超时，已完成%d/%d", progress.Done, progress.Total)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+//取消任务
+func (task *CopyMoveTask) Cancel() error {
+	d := url.Values{"task_id": {task.Id}}
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	body := bytes.NewBufferString(d.Encode())
+
+	resp, err := task.client.apiRequestV2p1("DELETE", "/copy-move-task/", header, body)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//批量删除中单个项目的结果
+type DeleteResult struct {
+	Name string
+	Err  error //为nil表示删除成功
+}
+
+//批量删除parentDir下的多个文件或文件夹，返回每个项目的删除结果
+//    删除前先获取目录内容，目录中不存在的项目返回错误，不会发送给服务器
+//    优先使用v2.1的批量删除接口，旧版服务器不支持时依次降级为v2的批量删除接口和逐个删除
+//    批量删除失败时，根据目录中是否还存在该项目判断每个项目的结果
+func (lib *Library) DeleteItems(parentDir string, names []string) ([]DeleteResult, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	entries, err := lib.ListDirectoryEntries(parentDir)
+	if err != nil {
+		return nil, fmt.Errorf("获取目录内容失败: %s", err)
+	}
+
+	isDir := map[string]bool{}
+	for _, entry := range entries {
+		isDir[entry.Name] = entry.Type == "dir"
+	}
+
+	var present []string
+	for _, name := range names {
+		if _, found := isDir[name]; found {
+			present = append(present, name)
+		}
+	}
+
+	var deleted []DeleteResult
+	if len(present) > 0 {
+		deleted = lib.deleteExistingItems(parentDir, present, isDir)
+	}
+
+	return mergeDeleteResults(names, deleted), nil
+}
+
+//删除目录中确定存在的项目
+func (lib *Library) deleteExistingItems(parentDir string, names []string, isDir map[string]bool) []DeleteResult {
+	unsupported, err := lib.batchDeleteItems(parentDir, names)
+	if !unsupported {
+		return lib.batchDeleteResults(parentDir, names, err)
+	}
+
+	//v2接口以:分隔文件名，文件名中含有:时会删除错误的项目
+	if !namesContain(names, ":") {
+		unsupported, err = lib.fileopsDeleteItems(parentDir, names)
+		if !unsupported {
+			return lib.batchDeleteResults(parentDir, names, err)
+		}
+	}
+
+	return lib.deleteItemsOneByOne(parentDir, names, isDir)
+}
+
+//是否有文件名包含s
+func namesContain(names []string, s string) bool {
+	for _, name := range names {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+//生成批量删除的结果，批量删除失败时重新获取目录内容判断每个项目的结果
+func (lib *Library) batchDeleteResults(parentDir string, names []string, err error) []DeleteResult {
+	if err == nil {
+		return checkDeleteResults(names, nil, nil)
+	}
+
+	entries, listErr := lib.ListDirectoryEntries(parentDir)
+	if listErr != nil {
+		return checkDeleteResults(names, nil, err)
+	}
+
+	remaining := map[string]bool{}
+	for _, entry := range entries {
+		remaining[entry.Name] = true
+	}
+
+	return checkDeleteResults(names, remaining, err)
+}
+
+//生成每个项目的删除结果
+//    err为nil时所有项目都删除成功
+//    err不为nil时，remaining中已不存在的项目视为删除成功，仍然存在的项目返回err
+//    remaining为nil表示无法获取目录内容，此时所有项目都返回err
+func checkDeleteResults(names []string, remaining map[string]bool, err error) []DeleteResult {
+	results := make([]DeleteResult, len(names))
+	for i, name := range names {
+		results[i] = DeleteResult{Name: name, Err: err}
+		if err != nil && remaining != nil && !remaining[name] {
+			results[i].Err = nil
+		}
+	}
+
+	return results
+}
+
+//按names的顺序合并删除结果，deleted中没有的项目返回不存在的错误
+func mergeDeleteResults(names []string, deleted []DeleteResult) []DeleteResult {
+	errs := map[string]error{}
+	for _, result := range deleted {
+		errs[result.Name] = result.Err
+	}
+
+	results := make([]DeleteResult, len(names))
+	for i, name := range names {
+		err, found := errs[name]
+		if !found {
+			err = fmt.Errorf("文件或文件夹不存在: %s", name)
+		}
+		results[i] = DeleteResult{Name: name, Err: err}
+	}
+
+	return results
+}
+
+//服务器是否不支持该接口
+//    接口不存在时返回405或非JSON的404页面，资料库或文件夹不存在时的404带有error_msg
+func isUnsupportedResponse(code int, body []byte) bool {
+	if code == http.StatusMethodNotAllowed {
+		return true
+	}
+
+	if code != http.StatusNotFound {
+		return false
+	}
+
+	var respInfo struct {
+		ErrorMsg string `json:"error_msg"`
+	}
+	err := json.Unmarshal(body, &respInfo)
+	return err != nil || respInfo.ErrorMsg == ""
+}
+
+//检查批量删除的返回值，返回值中的bool表示服务器不支持该接口
+func checkBatchDeleteResponse(resp *http.Response) (bool, error) {
+	if resp.StatusCode == http.StatusOK {
+		return false, nil
+	}
+
+	b, _ := ioutil.ReadAll(resp.Body)
+	if isUnsupportedResponse(resp.StatusCode, b) {
+		return true, nil
+	}
+
+	return false, fmt.Errorf("[%s] %s", resp.Status, string(b))
+}
+
+//使用v2.1接口批量删除，返回值中的bool表示服务器不支持该接口
+func (lib *Library) batchDeleteItems(parentDir string, names []string) (bool, error) {
+	req := struct {
+		RepoId    string   `json:"repo_id"`
+		ParentDir string   `json:"parent_dir"`
+		Dirents   []string `json:"dirents"`
+	}{lib.Id, parentDir, names}
+
+	resp, err := lib.client.apiRequestJSON("DELETE", "/repos/batch-delete-item/", req)
+	if err != nil {
+		return false, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkBatchDeleteResponse(resp)
+}
+
+//使用v2接口批量删除，返回值中的bool表示服务器不支持该接口
+//    文件名以:分隔，调用者需要确保文件名中不含:
+func (lib *Library) fileopsDeleteItems(parentDir string, names []string) (bool, error) {
+	q := url.Values{"p": {parentDir}}
+	d := url.Values{"file_names": {strings.Join(names, ":")}}
+	hdr := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+
+	resp, err := lib.doRequest("POST", "/fileops/delete/?"+q.Encode(), hdr, bytes.NewBufferString(d.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkBatchDeleteResponse(resp)
+}
+
+//逐个删除，isDir为目录中各项目是否为文件夹
+func (lib *Library) deleteItemsOneByOne(parentDir string, names []string, isDir map[string]bool) []DeleteResult {
+	results := make([]DeleteResult, len(names))
+	for i, name := range names {
+		results[i].Name = name
+
+		p := filepath.Join(parentDir, name)
+		if isDir[name] {
+			results[i].Err = lib.RemoveDirectory(p)
+		} else {
+			results[i].Err = lib.RemoveFile(p)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,91 @@
+package seafile
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIsUnsupportedResponse(t *testing.T) {
+	cases := []struct {
+		code int
+		body string
+		want bool
+	}{
+		{http.StatusMethodNotAllowed, `{"detail": "Method \"DELETE\" not allowed."}`, true},
+		{http.StatusNotFound, `<html>Page not found</html>`, true},
+		{http.StatusNotFound, ``, true},
+		{http.StatusNotFound, `{"error_msg": "Library not found."}`, false},
+		{http.StatusNotFound, `{"error_msg": "Folder /a not found."}`, false},
+		{http.StatusForbidden, `{"error_msg": "Permission denied."}`, false},
+		{http.StatusOK, `{"success": true}`, false},
+	}
+
+	for _, c := range cases {
+		got := isUnsupportedResponse(c.code, []byte(c.body))
+		if got != c.want {
+			t.Errorf("isUnsupportedResponse(%d, %s) = %v, 期望 %v", c.code, c.body, got, c.want)
+		}
+	}
+}
+
+func TestNamesContain(t *testing.T) {
+	if namesContain([]string{"a.txt", "b"}, ":") {
+		t.Error("不含:的文件名被误判")
+	}
+	if !namesContain([]string{"a.txt", "10:30.log"}, ":") {
+		t.Error("含:的文件名未被识别")
+	}
+	if namesContain(nil, ":") {
+		t.Error("空列表被误判")
+	}
+}
+
+func TestCheckDeleteResults(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	failed := fmt.Errorf("[500] error")
+
+	results := checkDeleteResults(names, nil, nil)
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("批量删除成功时%s不应返回错误: %s", result.Name, result.Err)
+		}
+	}
+
+	//部分删除成功，b仍在目录中
+	results = checkDeleteResults(names, map[string]bool{"b": true, "other": true}, failed)
+	for _, result := range results {
+		stillExists := result.Name == "b"
+		if (result.Err != nil) != stillExists {
+			t.Errorf("%s的删除结果错误: %v", result.Name, result.Err)
+		}
+	}
+
+	//无法获取目录内容
+	results = checkDeleteResults(names, nil, failed)
+	for _, result := range results {
+		if result.Err != failed {
+			t.Errorf("无法获取目录内容时%s应返回批量删除的错误: %v", result.Name, result.Err)
+		}
+	}
+}
+
+func TestMergeDeleteResults(t *testing.T) {
+	failed := fmt.Errorf("[403] error")
+	deleted := []DeleteResult{{Name: "c", Err: failed}, {Name: "a"}}
+
+	results := mergeDeleteResults([]string{"a", "missing", "c"}, deleted)
+	if len(results) != 3 {
+		t.Fatalf("结果数量错误: %d", len(results))
+	}
+
+	if results[0].Name != "a" || results[0].Err != nil {
+		t.Errorf("a的结果错误: %+v", results[0])
+	}
+	if results[1].Name != "missing" || results[1].Err == nil {
+		t.Errorf("不存在的项目应返回错误: %+v", results[1])
+	}
+	if results[2].Name != "c" || results[2].Err != failed {
+		t.Errorf("c的结果错误: %+v", results[2])
+	}
+}
@@ -11,6 +11,10 @@ import (
 type Client struct {
 	Addr      string
 	authToken string
+
+	//文件服务器的根地址，即服务器配置中的FILE_SERVER_ROOT
+	//    API不提供该地址，为空时使用 Addr/seafhttp
+	FileServerRoot string
 }
 
 //新建一个Seafile客户端
@@ -36,7 +40,8 @@ func New(addr string, authParams ...string) *Client {
 }
 
 //发起携带Token的Seafile WEB API请求
-func (cli *Client) requestApi(apiPrefix, method, uri string, header http.Header, body io.Reader) (*http.Response, error) {
+//    apiPrefix为/api2或/api/v2.1，可供admin等子包使用
+func (cli *Client) RequestApi(apiPrefix, method, uri string, header http.Header, body io.Reader) (*http.Response, error) {
 	return cli.request(method, cli.Addr+apiPrefix+uri, header, body)
 }
 
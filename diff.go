@@ -0,0 +1,101 @@
+package seafile
+
+import (
+	"errors"
+	"strings"
+)
+
+//文本比较时允许的最大比较规模（两段文本去掉相同的首尾行后，行数的乘积）
+//    比较需要按该规模分配内存，每单位4字节
+const MaxDiffCells = 4 * 1024 * 1024
+
+//文本差异过大，无法比较
+var ErrDiffTooLarge = errors.New("文本差异过大，无法比较")
+
+//按行比较两段文本
+//    返回的每一行以一个标记字符开头:
+//      ' ' 两者相同的行
+//      '-' 仅在a中存在的行
+//      '+' 仅在b中存在的行
+//    差异部分的规模超过MaxDiffCells时返回ErrDiffTooLarge
+func DiffText(a, b string) (string, error) {
+	al := splitLines(a)
+	bl := splitLines(b)
+
+	//去掉相同的首尾行，缩小需要比较的范围
+	prefix := 0
+	for prefix < len(al) && prefix < len(bl) && al[prefix] == bl[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(al)-prefix && suffix < len(bl)-prefix && al[len(al)-1-suffix] == bl[len(bl)-1-suffix] {
+		suffix++
+	}
+
+	am := al[prefix : len(al)-suffix]
+	bm := bl[prefix : len(bl)-suffix]
+	if (len(am)+1)*(len(bm)+1) > MaxDiffCells {
+		return "", ErrDiffTooLarge
+	}
+
+	var sb strings.Builder
+	for _, line := range al[:prefix] {
+		sb.WriteString(" " + line + "\n")
+	}
+
+	diffLines(&sb, am, bm)
+
+	for _, line := range al[len(al)-suffix:] {
+		sb.WriteString(" " + line + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+//基于最长公共子序列比较两组行，结果写入sb
+func diffLines(sb *strings.Builder, al, bl []string) {
+	//lcs[i*cols+j]为al[i:]与bl[j:]的最长公共子序列长度
+	cols := len(bl) + 1
+	lcs := make([]int32, (len(al)+1)*cols)
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i*cols+j] = lcs[(i+1)*cols+j+1] + 1
+			} else if lcs[(i+1)*cols+j] >= lcs[i*cols+j+1] {
+				lcs[i*cols+j] = lcs[(i+1)*cols+j]
+			} else {
+				lcs[i*cols+j] = lcs[i*cols+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(al) && j < len(bl) {
+		switch {
+		case al[i] == bl[j]:
+			sb.WriteString(" " + al[i] + "\n")
+			i++
+			j++
+		case lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]:
+			sb.WriteString("-" + al[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+" + bl[j] + "\n")
+			j++
+		}
+	}
+	for ; i < len(al); i++ {
+		sb.WriteString("-" + al[i] + "\n")
+	}
+	for ; j < len(bl); j++ {
+		sb.WriteString("+" + bl[j] + "\n")
+	}
+}
+
+//拆分文本行，忽略末尾的换行符
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,50 @@
+package seafile
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffText(t *testing.T) {
+	cases := []struct {
+		a, b, diff string
+	}{
+		{"", "", ""},
+		{"a\nb\n", "a\nb\n", " a\n b\n"},
+		{"a\nb\nc\n", "a\nc\n", " a\n-b\n c\n"},
+		{"a\nc", "a\nb\nc", " a\n+b\n c\n"},
+		{"a\n", "b\n", "-a\n+b\n"},
+		{"x\na\ny\n", "x\nb\ny\n", " x\n-a\n+b\n y\n"},
+	}
+
+	for _, c := range cases {
+		diff, err := DiffText(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != c.diff {
+			t.Errorf("DiffText(%q, %q) = %q, 期望 %q", c.a, c.b, diff, c.diff)
+		}
+	}
+}
+
+func TestDiffTextTooLarge(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < 3000; i++ {
+		fmt.Fprintf(&a, "a%d\n", i)
+		fmt.Fprintf(&b, "b%d\n", i)
+	}
+
+	_, err := DiffText(a.String(), b.String())
+	if err != ErrDiffTooLarge {
+		t.Fatalf("期望ErrDiffTooLarge，实际为%v", err)
+	}
+
+	//相同的首尾行不计入比较规模
+	same := a.String()
+	_, err = DiffText(same+"x\n"+same, same+"y\n"+same)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
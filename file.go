@@ -14,30 +14,18 @@ import (
 //    fileContentMap的key是文件名，value是文件内容
 //当目标文件存在时，会自动重命名上传
 func (lib *Library) UploadFileContent(dir string, fileContentMap map[string][]byte) error {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	//填充文件内容
-	for filename, content := range fileContentMap {
-		part, err := writer.CreateFormFile("file", filename)
-		if err != nil {
-			return fmt.Errorf("创建Multipart错误:%s", err)
-		}
-		part.Write(content)
+	fields := map[string]string{
+		"replace":       "1",
+		"parent_dir":    "/",
+		"relative_path": dir,
 	}
-
-	//填充其他字段
-	writer.WriteField("replace", "1")
-	writer.WriteField("parent_dir", "/")
-	writer.WriteField("relative_path", dir)
-
-	err := writer.Close()
+	body, contentType, err := newUploadBody(fileContentMap, fields)
 	if err != nil {
-		return fmt.Errorf("写Multipart文件错误:%s", err)
+		return err
 	}
 
 	//设置请求Header
-	header := http.Header{"Content-Type": {writer.FormDataContentType()}}
+	header := http.Header{"Content-Type": {contentType}}
 
 	//获取上传地址
 	uploadLink, err := lib.UploadLink()
@@ -66,6 +54,35 @@ func (lib *Library) UploadFileContent(dir string, fileContentMap map[string][]by
 	return nil
 }
 
+//生成上传文件的Multipart请求体
+//    fileContentMap的key是文件名，value是文件内容，fields为其他表单字段
+//    返回请求体及对应的Content-Type
+func newUploadBody(fileContentMap map[string][]byte, fields map[string]string) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	//填充文件内容
+	for filename, content := range fileContentMap {
+		part, err := writer.CreateFormFile("file", filename)
+		if err != nil {
+			return nil, "", fmt.Errorf("创建Multipart错误:%s", err)
+		}
+		part.Write(content)
+	}
+
+	//填充其他字段
+	for k, v := range fields {
+		writer.WriteField(k, v)
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("写Multipart文件错误:%s", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
+
 //删除文件
 func (lib *Library) RemoveFile(file string) error {
 	query := url.Values{"p": {file}}
@@ -0,0 +1,227 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//群组
+type Group struct {
+	Id        int
+	Name      string
+	Owner     string
+	Admins    []string
+	CreatedAt string `json:"created_at"`
+	AvatarUrl string `json:"avatar_url"`
+}
+
+//群组成员
+type GroupMember struct {
+	Email        string
+	Name         string
+	ContactEmail string `json:"contact_email"`
+	LoginId      string `json:"login_id"`
+	Role         string //Owner、Admin或Member
+	IsAdmin      bool   `json:"is_admin"`
+	AvatarUrl    string `json:"avatar_url"`
+}
+
+//群组的资源地址
+func groupUri(groupID int) string {
+	return "/groups/" + strconv.Itoa(groupID) + "/"
+}
+
+//获取当前用户所在的群组
+func (cli *Client) ListGroups() ([]*Group, error) {
+	resp, err := cli.apiGET("/groups/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var groups []*Group
+	err = decodeResponse(resp, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+//创建群组
+func (cli *Client) CreateGroup(name string) (*Group, error) {
+	resp, err := cli.apiPOSTForm("/groups/", url.Values{"name": {name}})
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var group Group
+	err = decodeResponse(resp, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+//重命名群组
+func (cli *Client) RenameGroup(groupID int, name string) error {
+	resp, err := cli.apiPUTForm(groupUri(groupID), url.Values{"name": {name}})
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//删除群组
+func (cli *Client) DeleteGroup(groupID int) error {
+	resp, err := cli.apiDELETE(groupUri(groupID))
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//获取群组成员
+func (cli *Client) ListGroupMembers(groupID int) ([]*GroupMember, error) {
+	resp, err := cli.apiGET(groupUri(groupID) + "members/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var members []*GroupMember
+	err = decodeResponse(resp, &members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+//批量添加群组成员
+//    部分用户添加失败时，返回的错误中包含失败的用户及原因
+func (cli *Client) AddGroupMembers(groupID int, emails []string) error {
+	d := url.Values{"emails": {strings.Join(emails, ",")}}
+	resp, err := cli.apiPOSTForm(groupUri(groupID)+"members/bulk/", d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		Failed []struct {
+			Email    string
+			ErrorMsg string `json:"error_msg"`
+		}
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return err
+	}
+
+	if len(respInfo.Failed) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(respInfo.Failed))
+	for _, f := range respInfo.Failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", f.Email, f.ErrorMsg))
+	}
+
+	return fmt.Errorf("部分成员添加失败: %s", strings.Join(msgs, "; "))
+}
+
+//移除群组成员
+func (cli *Client) RemoveGroupMember(groupID int, email string) error {
+	resp, err := cli.apiDELETE(groupUri(groupID) + "members/" + url.PathEscape(email) + "/")
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//设置或取消群组管理员
+func (cli *Client) SetGroupAdmin(groupID int, email string, isAdmin bool) error {
+	d := url.Values{"is_admin": {strconv.FormatBool(isAdmin)}}
+	resp, err := cli.apiPUTForm(groupUri(groupID)+"members/"+url.PathEscape(email)+"/", d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//群组资料库接口返回的资料库信息
+type groupLibrary struct {
+	RepoId     string `json:"repo_id"`
+	RepoName   string `json:"repo_name"`
+	OwnerEmail string `json:"owner_email"`
+	Permission string
+	Encrypted  bool
+	Size       int
+}
+
+//转换为资料库
+func (gl *groupLibrary) library(cli *Client) *Library {
+	return &Library{
+		Id:         gl.RepoId,
+		Name:       gl.RepoName,
+		Type:       LibraryTypeGroup,
+		Owner:      gl.OwnerEmail,
+		Permission: gl.Permission,
+		Encrypted:  gl.Encrypted,
+		Size:       gl.Size,
+		client:     cli,
+	}
+}
+
+//获取指定群组的资料库
+func (cli *Client) listLibrariesOfGroup(groupID int) ([]*Library, error) {
+	resp, err := cli.apiGET(groupUri(groupID) + "libraries/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo []groupLibrary
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	libraries := make([]*Library, 0, len(respInfo))
+	for i := range respInfo {
+		libraries = append(libraries, respInfo[i].library(cli))
+	}
+
+	return libraries, nil
+}
+
+//在群组中创建资料库
+func (cli *Client) CreateGroupLibrary(groupID int, name string) (*Library, error) {
+	d := url.Values{"repo_name": {name}}
+	resp, err := cli.apiPOSTForm(groupUri(groupID)+"libraries/", d)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo groupLibrary
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return respInfo.library(cli), nil
+}
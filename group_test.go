@@ -0,0 +1,28 @@
+package seafile
+
+import (
+	"os"
+	"testing"
+)
+
+func TestListGroups(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	groups, err := client.ListGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, group := range groups {
+		t.Logf("%+v", group)
+
+		libraries, err := client.ListGroupLibraries(group.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, lib := range libraries {
+			t.Logf("  %s %s", lib.Id, lib.Name)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+//提交时间
+func (commit *LibraryCommit) Time() time.Time {
+	return time.Unix(int64(commit.Ctime), 0)
+}
+
+//获取资料库指定页的提交历史
+//    page从1开始，perPage为0时使用服务器默认值
+//    返回值中的bool表示是否还有下一页
+func (lib *Library) HistoryPage(page, perPage int) ([]*LibraryCommit, bool, error) {
+	q := url.Values{}
+	if page > 0 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		q.Set("per_page", strconv.Itoa(perPage))
+	}
+
+	uri := "/history/"
+	if len(q) > 0 {
+		uri += "?" + q.Encode()
+	}
+
+	resp, err := lib.doRequest("GET", uri, nil, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		PageNext bool `json:"page_next"`
+		Commits  []*LibraryCommit
+	}
+
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, commit := range respInfo.Commits {
+		commit.library = lib
+	}
+
+	return respInfo.Commits, respInfo.PageNext, nil
+}
+
+//资料库提交历史迭代器
+//    按时间由新到旧逐条返回提交，当前页取完后才请求下一页
+//  用法:
+//    it := lib.IterateHistory(100)
+//    it.Since = time.Now().AddDate(0, 0, -7)
+//    for it.Next() {
+//        commit := it.Commit()
+//    }
+//    if err := it.Err(); err != nil {
+//    }
+type HistoryIterator struct {
+	Since time.Time //早于该时间的提交会结束迭代，零值表示不限制
+	Until time.Time //晚于该时间的提交会被跳过，零值表示不限制
+
+	lib      *Library
+	page     int
+	perPage  int
+	pageNext bool
+	commits  []*LibraryCommit
+	current  *LibraryCommit
+	err      error
+}
+
+//创建资料库提交历史迭代器
+//    perPage为每次请求的提交数量，为0时使用服务器默认值
+func (lib *Library) IterateHistory(perPage int) *HistoryIterator {
+	return &HistoryIterator{
+		lib:      lib,
+		perPage:  perPage,
+		pageNext: true,
+	}
+}
+
+//移动到下一个提交，没有更多提交或出错时返回false
+func (it *HistoryIterator) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.commits) == 0 {
+			if !it.pageNext {
+				return false
+			}
+
+			it.page++
+			it.commits, it.pageNext, it.err = it.lib.HistoryPage(it.page, it.perPage)
+			continue
+		}
+
+		commit := it.commits[0]
+		it.commits = it.commits[1:]
+
+		t := commit.Time()
+		if !it.Since.IsZero() && t.Before(it.Since) {
+			//提交按时间倒序排列，后面的提交只会更早
+			it.commits = nil
+			it.pageNext = false
+			return false
+		}
+
+		if !it.Until.IsZero() && t.After(it.Until) {
+			continue
+		}
+
+		it.current = commit
+		return true
+	}
+}
+
+//当前提交
+func (it *HistoryIterator) Commit() *LibraryCommit {
+	return it.current
+}
+
+//迭代过程中发生的错误
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+//获取指定提交的详细信息
+//    服务器没有直接获取单个提交的接口，需要从最新的提交开始遍历提交历史查找
+//    limit为最多检查的提交数量，为0时不限制，此时提交不存在会遍历整个历史
+func (lib *Library) GetCommit(commitID string, limit int) (*LibraryCommit, error) {
+	it := lib.IterateHistory(100)
+	for n := 0; (limit <= 0 || n < limit) && it.Next(); n++ {
+		if it.Commit().Id == commitID {
+			return it.Commit(), nil
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("未找到提交: %s", commitID)
+}
+
+//提交中的变更
+type LibraryCommitChanges struct {
+	AddedFiles    []string `json:"added_files"`
+	DeletedFiles  []string `json:"deleted_files"`
+	ModifiedFiles []string `json:"modified_files"`
+	RenamedFiles  []string `json:"renamed_files"` //按 旧路径,新路径 成对排列
+	AddedDirs     []string `json:"added_dirs"`
+	DeletedDirs   []string `json:"deleted_dirs"`
+}
+
+//获取指定提交中变更的文件列表
+func (lib *Library) CommitChanges(commitID string) (*LibraryCommitChanges, error) {
+	q := url.Values{"commit_id": {commitID}}
+	resp, err := lib.client.doRequest("GET", "/repo_history_changes/"+lib.Id+"/?"+q.Encode(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var changes LibraryCommitChanges
+	err = decodeResponse(resp, &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
+
+//获取该提交中变更的文件列表
+func (commit *LibraryCommit) Changes() (*LibraryCommitChanges, error) {
+	if commit.library == nil {
+		return nil, fmt.Errorf("提交未关联资料库")
+	}
+	return commit.library.CommitChanges(commit.Id)
+}
+
+//变更涉及的所有路径（重命名的文件包含新旧两个路径）
+func (changes *LibraryCommitChanges) Paths() []string {
+	var paths []string
+	paths = append(paths, changes.AddedFiles...)
+	paths = append(paths, changes.DeletedFiles...)
+	paths = append(paths, changes.ModifiedFiles...)
+	paths = append(paths, changes.RenamedFiles...)
+	paths = append(paths, changes.AddedDirs...)
+	paths = append(paths, changes.DeletedDirs...)
+	return paths
+}
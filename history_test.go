@@ -0,0 +1,56 @@
+package seafile
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIterateHistory(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	lib, err := client.GetLibrary(os.Getenv("SEAFILE_REPO"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := lib.IterateHistory(5)
+	count := 0
+	for it.Next() && count < 12 {
+		commit := it.Commit()
+		t.Logf("%s %s %s", commit.Id, commit.Time(), commit.Desc)
+		count++
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if count == 0 {
+		t.Skip("资料库没有提交历史")
+	}
+}
+
+func TestCommitChanges(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	lib, err := client.GetLibrary(os.Getenv("SEAFILE_REPO"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := lib.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(commits) == 0 {
+		t.Skip("资料库没有提交历史")
+	}
+
+	changes, err := commits[0].Changes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("%+v", changes)
+}
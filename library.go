@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"strings"
 )
@@ -54,8 +53,22 @@ func (cli *Client) ListSharedLibraries() ([]*Library, error) {
 }
 
 //获取群组共享而来的资料库
-func (cli *Client) ListGroupLibraries() ([]*Library, error) {
-	return cli.ListLibrariesByType(LibraryTypeGroup)
+//    指定groupIDs时，只获取这些群组的资料库
+func (cli *Client) ListGroupLibraries(groupIDs ...int) ([]*Library, error) {
+	if len(groupIDs) == 0 {
+		return cli.ListLibrariesByType(LibraryTypeGroup)
+	}
+
+	libraries := []*Library{}
+	for _, id := range groupIDs {
+		libs, err := cli.listLibrariesOfGroup(id)
+		if err != nil {
+			return nil, fmt.Errorf("获取群组%d的资料库失败: %s", id, err)
+		}
+		libraries = append(libraries, libs...)
+	}
+
+	return libraries, nil
 }
 
 //获取公共的资料库
@@ -159,6 +172,15 @@ func (lib *Library) doRequest(method, uri string, header http.Header, body io.Re
 	return lib.client.doRequest(method, uri, header, body)
 }
 
+//以v2.1接口的资料库形式访问
+func (lib *Library) repo() *Repo {
+	return &Repo{
+		Id:     lib.Id,
+		Name:   lib.Name,
+		client: lib.client,
+	}
+}
+
 //获取资料库的上传地址
 func (lib *Library) UploadLink() (string, error) {
 	resp, err := lib.doRequest("GET", "/upload-link/", nil, nil)
@@ -197,32 +219,9 @@ type LibraryCommit struct {
 	library *Library `json:"-"`
 }
 
-//获取资料库的提交历史
+//获取资料库的提交历史（第一页）
+//    如需获取全部历史，请使用IterateHistory
 func (lib *Library) History() ([]*LibraryCommit, error) {
-	resp, err := lib.doRequest("GET", "/history", nil, nil)
-	if err != nil {
-		return nil, fmt.Errorf("请求错误:%s", err)
-	}
-	defer resp.Body.Close()
-
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("读取错误: %s", err)
-	}
-
-	var respInfo struct {
-		PageNext bool `json:"page_next"`
-		Commits  []*LibraryCommit
-	}
-
-	err = json.Unmarshal(b, &respInfo)
-	if err != nil {
-		return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
-	}
-
-	for _, commit := range respInfo.Commits {
-		commit.library = lib
-	}
-
-	return respInfo.Commits, nil
+	commits, _, err := lib.HistoryPage(1, 0)
+	return commits, err
 }
@@ -0,0 +1,113 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+//通知类型
+type NotificationType string
+
+const (
+	NotificationRepoShare        NotificationType = "repo_share"          //资料库共享给我
+	NotificationRepoShareToGroup NotificationType = "repo_share_to_group" //资料库共享给我所在的群组
+	NotificationGroupMessage     NotificationType = "group_msg"           //群组消息
+	NotificationAddUserToGroup   NotificationType = "add_user_to_group"   //被加入群组
+	NotificationFileComment      NotificationType = "file_comment"        //文件评论
+	NotificationFileUploaded     NotificationType = "file_uploaded"       //有人通过上传链接上传了文件
+	NotificationUserMessage      NotificationType = "user_message"        //私信
+)
+
+//通知
+type Notification struct {
+	Id     int
+	Type   NotificationType
+	Seen   bool
+	Time   time.Time
+	Detail map[string]interface{} //内容随通知类型不同而不同
+}
+
+//通知详情中的字符串字段，不存在时返回空字符串
+func (n *Notification) DetailString(key string) string {
+	s, _ := n.Detail[key].(string)
+	return s
+}
+
+//获取通知列表和未读数量
+func (cli *Client) notifications(page, perPage int) ([]*Notification, int, error) {
+	q := url.Values{
+		"page":     {strconv.Itoa(page)},
+		"per_page": {strconv.Itoa(perPage)},
+	}
+
+	resp, err := cli.apiGET("/notifications/?" + q.Encode())
+	if err != nil {
+		return nil, 0, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		NotificationList []*Notification `json:"notification_list"`
+		UnseenCount      int             `json:"unseen_count"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return respInfo.NotificationList, respInfo.UnseenCount, nil
+}
+
+//获取最近的通知，按时间由新到旧排列
+func (cli *Client) Notifications() ([]*Notification, error) {
+	return cli.NotificationsPage(1, 25)
+}
+
+//获取指定页的通知，按时间由新到旧排列
+//    page从1开始
+func (cli *Client) NotificationsPage(page, perPage int) ([]*Notification, error) {
+	notifications, _, err := cli.notifications(page, perPage)
+	return notifications, err
+}
+
+//获取未读通知数量
+func (cli *Client) UnseenNotificationCount() (int, error) {
+	_, count, err := cli.notifications(1, 1)
+	return count, err
+}
+
+//将所有通知标记为已读
+func (cli *Client) MarkNotificationsSeen() error {
+	resp, err := cli.apiPUT("/notifications/", nil, nil)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//将指定通知标记为已读
+func (cli *Client) MarkNotificationSeen(id int) error {
+	d := url.Values{"notice_id": {strconv.Itoa(id)}}
+	resp, err := cli.apiPUTForm("/notification/", d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//删除所有通知
+func (cli *Client) DeleteNotifications() error {
+	resp, err := cli.apiDELETE("/notifications/")
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-http/seafile/admin"
+)
+
+//admin命令的用法
+const CommandAdminUsage = `
+  admin logs export --since 日期 [--until 日期] [--type 日志类型,...]
+                   以JSON Lines格式输出审计日志，需要系统管理员账号
+                   日期格式为2006-01-02或RFC3339
+                   日志类型: login、file-access、file-update、share-permission，默认全部
+
+  eg:
+     admin logs export --since 2024-01-01 > audit.jsonl
+`
+
+func init() {
+	RegisterCommand("admin", CommandAdminUsage, CommandAdmin)
+}
+
+//admin命令
+func CommandAdmin(args ...string) {
+	if len(args) < 2 || args[0] != "logs" || args[1] != "export" {
+		fmt.Fprintf(os.Stderr, "用法:%s\n", CommandAdminUsage)
+		return
+	}
+
+	err := exportLogs(args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "导出日志失败: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+//日志迭代器
+type logIterator interface {
+	Next() bool
+	Raw() json.RawMessage
+	Err() error
+}
+
+//所有支持导出的日志类型
+var logTypes = []string{"login", "file-access", "file-update", "share-permission"}
+
+//解析日期参数
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+//导出审计日志
+func exportLogs(args []string) error {
+	fs := flag.NewFlagSet("admin logs export", flag.ContinueOnError)
+	sinceStr := fs.String("since", "", "起始时间")
+	untilStr := fs.String("until", "", "截止时间")
+	types := fs.String("type", strings.Join(logTypes, ","), "日志类型")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *sinceStr == "" {
+		return fmt.Errorf("必须指定--since")
+	}
+
+	since, err := parseTime(*sinceStr)
+	if err != nil {
+		return fmt.Errorf("无法解析--since: %s", err)
+	}
+
+	until, err := parseTime(*untilStr)
+	if err != nil {
+		return fmt.Errorf("无法解析--until: %s", err)
+	}
+
+	adm, err := admin.New(sf)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, logType := range strings.Split(*types, ",") {
+		var it logIterator
+		switch logType {
+		case "login":
+			it = adm.IterateLoginLogs(since, until)
+		case "file-access":
+			it = adm.IterateFileAccessLogs(since, until)
+		case "file-update":
+			it = adm.IterateFileUpdateLogs(since, until)
+		case "share-permission":
+			it = adm.IterateSharePermissionLogs(since, until)
+		default:
+			return fmt.Errorf("不支持的日志类型: %s", logType)
+		}
+
+		err = writeLogs(w, logType, it)
+		if err != nil {
+			return fmt.Errorf("%s: %s", logType, err)
+		}
+	}
+
+	return nil
+}
+
+//将日志逐行写入，每行附加log_type字段
+func writeLogs(w *bufio.Writer, logType string, it logIterator) error {
+	for it.Next() {
+		var item map[string]interface{}
+		err := json.Unmarshal(it.Raw(), &item)
+		if err != nil {
+			return err
+		}
+
+		item["log_type"] = logType
+
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+
+		w.Write(b)
+		w.WriteByte('\n')
+	}
+
+	return it.Err()
+}
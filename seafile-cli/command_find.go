@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-http/seafile"
+)
+
+//find命令的用法
+const CommandFindUsage = `
+  find 关键字               在所有资料库中搜索
+  find 资料库名/路径 关键字 在指定资料库的指定路径下搜索
+                            服务器不支持搜索时，逐个遍历目录按文件名匹配
+`
+
+func init() {
+	RegisterCommand("find", CommandFindUsage, CommandFind)
+}
+
+//find命令
+func CommandFind(args ...string) {
+	var keyword string
+	var library *seafile.Library
+	var dir string
+
+	switch len(args) {
+	case 1:
+		keyword = args[0]
+	case 2:
+		keyword = args[1]
+
+		var libName string
+		libName, dir = parseDirectory(args[0])
+
+		var err error
+		library, err = sf.GetLibrary(libName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "获取资料库失败: %s\n", err)
+			return
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "用法:%s\n", CommandFindUsage)
+		return
+	}
+
+	opts := &seafile.SearchOptions{}
+	if library != nil {
+		opts.RepoId = library.Id
+		if dir != "/" {
+			opts.PathPrefix = dir
+		}
+	}
+
+	hits, err := sf.Search(keyword, opts)
+	if err == seafile.ErrSearchUnsupported {
+		findByWalk(keyword, library, dir)
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "搜索失败: %s\n", err)
+		return
+	}
+
+	for _, hit := range hits {
+		name := hit.RepoName + hit.Path
+		if hit.IsDir {
+			name += "/"
+		}
+		fmt.Println(name)
+	}
+}
+
+//遍历目录按文件名查找
+func findByWalk(keyword string, library *seafile.Library, dir string) {
+	libraries := []*seafile.Library{library}
+	if library == nil {
+		var err error
+		libraries, err = sf.ListAllLibraries()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "获取资料库列表失败: %s\n", err)
+			return
+		}
+		dir = "/"
+	}
+
+	keyword = strings.ToLower(keyword)
+	for _, lib := range libraries {
+		walk(lib, dir, keyword)
+	}
+}
+
+//递归遍历目录
+func walk(library *seafile.Library, dir, keyword string) {
+	entries, err := library.ListDirectoryEntries(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "获取文件夹内容失败%s%s: %s\n", library.Name, dir, err)
+		return
+	}
+
+	for _, e := range entries {
+		p := filepath.Join(dir, e.Name)
+		if strings.Contains(strings.ToLower(e.Name), keyword) {
+			name := library.Name + p
+			if e.Type == "dir" {
+				name += "/"
+			}
+			fmt.Println(name)
+		}
+
+		if e.Type == "dir" {
+			walk(library, p, keyword)
+		}
+	}
+}
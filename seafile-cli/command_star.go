@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+//star命令的用法
+const CommandStarUsage = `
+  star                    查看星标文件和文件夹
+  star add 资料库名/路径  添加星标
+  star rm 资料库名/路径   取消星标
+`
+
+func init() {
+	RegisterCommand("star", CommandStarUsage, CommandStar)
+}
+
+//star命令
+func CommandStar(args ...string) {
+	if len(args) == 0 {
+		items, err := sf.StarredItems()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "获取星标失败: %s\n", err)
+			return
+		}
+
+		for _, item := range items {
+			name := item.RepoName + strings.TrimSuffix(item.Path, "/")
+			if item.IsDir {
+				name += "/"
+			}
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if len(args) != 2 || (args[0] != "add" && args[0] != "rm") {
+		fmt.Fprintf(os.Stderr, "用法:%s\n", CommandStarUsage)
+		return
+	}
+
+	libName, path := parseDirectory(args[1])
+	library, err := sf.GetLibrary(libName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "获取资料库失败: %s\n", err)
+		return
+	}
+
+	if args[0] == "add" {
+		err = library.Star(path)
+	} else {
+		err = library.Unstar(path)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "操作失败: %s\n", err)
+	}
+}
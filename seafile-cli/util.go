@@ -8,11 +8,15 @@ import (
 //解析文件夹参数
 //    以/开头的参数，表示默认资料库的下文件夹完整路径
 //    非/开头的参数，/前表示资料库名，/及之后表示文件夹的完整路径
+//    不含/的参数表示资料库的根目录
 func parseDirectory(directory string) (string, string) {
 	if strings.HasPrefix(directory, "/") {
 		return "", directory
 	} else {
 		strs := strings.SplitN(directory, "/", 2)
+		if len(strs) == 1 {
+			return strs[0], "/"
+		}
 		return strs[0], "/" + strs[1]
 	}
 }
@@ -0,0 +1,285 @@
+package seafile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//搜索接口中文件大小的单位
+const sizeMB = 1024 * 1024
+
+//服务器不支持搜索
+var ErrSearchUnsupported = errors.New("服务器不支持搜索")
+
+//搜索的文件类型
+const (
+	SearchTypeText     = "Text"
+	SearchTypeDocument = "Document"
+	SearchTypeImage    = "Image"
+	SearchTypeVideo    = "Video"
+	SearchTypeAudio    = "Audio"
+	SearchTypePDF      = "PDF"
+	SearchTypeMarkdown = "Markdown"
+)
+
+//各文件类型对应的扩展名，用于社区版的客户端过滤
+var searchTypeExts = map[string][]string{
+	SearchTypeText:     {".txt", ".log", ".csv", ".json", ".xml", ".yaml", ".yml", ".ini", ".conf"},
+	SearchTypeDocument: {".doc", ".docx", ".xls", ".xlsx", ".ppt", ".pptx", ".odt", ".ods", ".odp"},
+	SearchTypeImage:    {".jpg", ".jpeg", ".png", ".gif", ".bmp", ".webp", ".svg", ".tif", ".tiff"},
+	SearchTypeVideo:    {".mp4", ".mkv", ".avi", ".mov", ".wmv", ".flv", ".webm"},
+	SearchTypeAudio:    {".mp3", ".wav", ".flac", ".aac", ".ogg", ".m4a"},
+	SearchTypePDF:      {".pdf"},
+	SearchTypeMarkdown: {".md", ".markdown"},
+}
+
+//搜索选项，零值表示不限制
+type SearchOptions struct {
+	RepoId     string    //只搜索指定资料库
+	PathPrefix string    //只搜索指定目录，需要同时指定RepoId
+	FileTypes  []string  //SearchTypeText等
+	TimeFrom   time.Time //修改时间范围
+	TimeTo     time.Time
+	SizeFrom   int64 //文件大小范围，单位为字节
+	SizeTo     int64
+	Page       int //从1开始，仅专业版有效
+	PerPage    int //仅专业版有效
+}
+
+//搜索结果
+type SearchHit struct {
+	RepoId           string `json:"repo_id"`
+	RepoName         string `json:"repo_name"`
+	Name             string `json:"name"`
+	Path             string `json:"fullpath"`
+	IsDir            bool   `json:"is_dir"`
+	Size             int64  `json:"size"`
+	LastModified     int64  `json:"last_modified"`
+	ContentHighlight string `json:"content_highlight"` //匹配内容的摘要，仅专业版全文搜索有效
+}
+
+//修改时间
+func (hit *SearchHit) Mtime() time.Time {
+	return time.Unix(hit.LastModified, 0)
+}
+
+//搜索文件
+//    优先使用专业版的全文搜索接口，不可用时降级为社区版的文件名搜索
+//    两者都不可用时返回ErrSearchUnsupported
+func (cli *Client) Search(query string, opts *SearchOptions) ([]*SearchHit, error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+
+	hits, err := cli.searchPro(query, opts)
+	if err != ErrSearchUnsupported {
+		return hits, err
+	}
+
+	return cli.searchFilename(query, opts)
+}
+
+//专业版全文搜索
+func (cli *Client) searchPro(query string, opts *SearchOptions) ([]*SearchHit, error) {
+	q := url.Values{"q": {query}}
+
+	if opts.RepoId != "" {
+		q.Set("search_repo", opts.RepoId)
+		if opts.PathPrefix != "" {
+			q.Set("search_path", opts.PathPrefix)
+		}
+	}
+
+	if len(opts.FileTypes) > 0 {
+		q.Set("search_ftypes", "custom")
+		q["ftype"] = opts.FileTypes
+	}
+
+	if !opts.TimeFrom.IsZero() {
+		q.Set("time_from", strconv.FormatInt(opts.TimeFrom.Unix(), 10))
+	}
+	if !opts.TimeTo.IsZero() {
+		q.Set("time_to", strconv.FormatInt(opts.TimeTo.Unix(), 10))
+	}
+
+	//接口的大小单位为MB
+	if opts.SizeFrom > 0 {
+		q.Set("size_from", strconv.FormatInt(opts.SizeFrom/sizeMB, 10))
+	}
+	if opts.SizeTo > 0 {
+		q.Set("size_to", strconv.FormatInt((opts.SizeTo+sizeMB-1)/sizeMB, 10))
+	}
+
+	if opts.Page > 0 {
+		q.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+
+	resp, err := cli.doRequest("GET", "/search/?"+q.Encode(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrSearchUnsupported
+	}
+
+	var respInfo struct {
+		Results []*SearchHit
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return respInfo.Results, nil
+}
+
+//社区版文件名搜索，过滤条件在客户端处理
+//    跳过搜索失败的资料库，所有资料库都失败时返回第一个错误
+func (cli *Client) searchFilename(query string, opts *SearchOptions) ([]*SearchHit, error) {
+	var libraries []*Library
+	if opts.RepoId != "" {
+		libraries = []*Library{{Id: opts.RepoId, client: cli}}
+	} else {
+		var err error
+		libraries, err = cli.ListAllLibraries()
+		if err != nil {
+			return nil, fmt.Errorf("获取资料库列表失败: %s", err)
+		}
+	}
+
+	var hits []*SearchHit
+	var firstErr error
+	failed := 0
+	for _, lib := range libraries {
+		libHits, err := lib.searchFilename(query)
+		if err == ErrSearchUnsupported {
+			return nil, err
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("搜索资料库%s失败: %s", lib.Name, err)
+			}
+			failed++
+			continue
+		}
+
+		for _, hit := range libHits {
+			if opts.match(hit) {
+				hits = append(hits, hit)
+			}
+		}
+	}
+
+	if failed > 0 && failed == len(libraries) {
+		return nil, firstErr
+	}
+
+	return hits, nil
+}
+
+//在单个资料库中搜索文件名
+//    接口不存在时返回ErrSearchUnsupported，资料库不存在等错误原样返回
+func (lib *Library) searchFilename(query string) ([]*SearchHit, error) {
+	q := url.Values{"repo_id": {lib.Id}, "q": {query}}
+	resp, err := lib.client.apiGET("/search-file/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if isUnsupportedResponse(resp.StatusCode, b) {
+		return nil, ErrSearchUnsupported
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	var respInfo struct {
+		Data []struct {
+			Path  string
+			Size  int64
+			Mtime int64
+			Type  string
+		}
+	}
+	err = json.Unmarshal(b, &respInfo)
+	if err != nil {
+		return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	var hits []*SearchHit
+	for _, d := range respInfo.Data {
+		hits = append(hits, &SearchHit{
+			RepoId:       lib.Id,
+			RepoName:     lib.Name,
+			Name:         filepath.Base(d.Path),
+			Path:         d.Path,
+			IsDir:        d.Type == "dir",
+			Size:         d.Size,
+			LastModified: d.Mtime,
+		})
+	}
+
+	return hits, nil
+}
+
+//判断搜索结果是否符合过滤条件
+func (opts *SearchOptions) match(hit *SearchHit) bool {
+	if opts.PathPrefix != "" {
+		prefix := strings.TrimSuffix(opts.PathPrefix, "/") + "/"
+		if !strings.HasPrefix(hit.Path, prefix) {
+			return false
+		}
+	}
+
+	if !opts.TimeFrom.IsZero() && hit.Mtime().Before(opts.TimeFrom) {
+		return false
+	}
+	if !opts.TimeTo.IsZero() && hit.Mtime().After(opts.TimeTo) {
+		return false
+	}
+
+	if opts.SizeFrom > 0 && hit.Size < opts.SizeFrom {
+		return false
+	}
+	if opts.SizeTo > 0 && hit.Size > opts.SizeTo {
+		return false
+	}
+
+	if len(opts.FileTypes) > 0 {
+		if hit.IsDir {
+			return false
+		}
+
+		ext := strings.ToLower(filepath.Ext(hit.Name))
+		for _, t := range opts.FileTypes {
+			for _, e := range searchTypeExts[t] {
+				if e == ext {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return true
+}
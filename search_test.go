@@ -0,0 +1,31 @@
+package seafile
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchOptionsMatch(t *testing.T) {
+	hit := &SearchHit{Name: "report.pdf", Path: "/docs/report.pdf", Size: 2048, LastModified: 1600000000}
+
+	cases := []struct {
+		opts  SearchOptions
+		match bool
+	}{
+		{SearchOptions{}, true},
+		{SearchOptions{PathPrefix: "/docs"}, true},
+		{SearchOptions{PathPrefix: "/doc"}, false},
+		{SearchOptions{FileTypes: []string{SearchTypePDF}}, true},
+		{SearchOptions{FileTypes: []string{SearchTypeImage}}, false},
+		{SearchOptions{SizeFrom: 4096}, false},
+		{SearchOptions{SizeTo: 1024}, false},
+		{SearchOptions{TimeFrom: time.Unix(1500000000, 0), TimeTo: time.Unix(1700000000, 0)}, true},
+		{SearchOptions{TimeFrom: time.Unix(1700000000, 0)}, false},
+	}
+
+	for i, c := range cases {
+		if c.opts.match(hit) != c.match {
+			t.Errorf("第%d个条件%+v的匹配结果应为%v", i, c.opts, c.match)
+		}
+	}
+}
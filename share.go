@@ -0,0 +1,176 @@
+package seafile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	ShareTypeUser  = "user"  //共享给用户
+	ShareTypeGroup = "group" //共享给群组
+)
+
+const (
+	PermissionRead      = "r"     //只读
+	PermissionReadWrite = "rw"    //读写
+	PermissionAdmin     = "admin" //管理员，仅对整个资料库的共享有效
+)
+
+//自定义共享权限，id为在网页端创建的自定义权限ID
+func CustomPermission(id int) string {
+	return "custom-" + strconv.Itoa(id)
+}
+
+//资料库或文件夹的共享对象
+type ShareItem struct {
+	ShareType  string //ShareTypeUser或ShareTypeGroup
+	Permission string
+	IsAdmin    bool
+
+	UserEmail string //仅ShareTypeUser有效
+	UserName  string //仅ShareTypeUser有效
+	GroupId   int    //仅ShareTypeGroup有效
+	GroupName string //仅ShareTypeGroup有效
+}
+
+//执行共享相关请求
+//    d为表单内容，v为nil时不解析返回值
+func (lib *Library) sharedItemsRequest(method string, q url.Values, d url.Values, v interface{}) error {
+	var header http.Header
+	var body io.Reader
+	if d != nil {
+		header = http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+		body = bytes.NewBufferString(d.Encode())
+	}
+
+	resp, err := lib.doRequest(method, "/dir/shared_items/?"+q.Encode(), header, body)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if v == nil {
+		return checkResponse(resp)
+	}
+
+	return decodeResponse(resp, v)
+}
+
+//获取资料库中指定路径的共享对象
+//    path为"/"时表示整个资料库
+func (lib *Library) ListShareItems(path string) ([]ShareItem, error) {
+	q := url.Values{"p": {path}}
+
+	var respInfo []struct {
+		ShareType  string `json:"share_type"`
+		Permission string
+		IsAdmin    bool `json:"is_admin"`
+		UserInfo   struct {
+			Name     string
+			Nickname string
+		} `json:"user_info"`
+		GroupInfo struct {
+			Id   int
+			Name string
+		} `json:"group_info"`
+	}
+	err := lib.sharedItemsRequest("GET", q, nil, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ShareItem, 0, len(respInfo))
+	for _, info := range respInfo {
+		items = append(items, ShareItem{
+			ShareType:  info.ShareType,
+			Permission: info.Permission,
+			IsAdmin:    info.IsAdmin,
+			UserEmail:  info.UserInfo.Name,
+			UserName:   info.UserInfo.Nickname,
+			GroupId:    info.GroupInfo.Id,
+			GroupName:  info.GroupInfo.Name,
+		})
+	}
+
+	return items, nil
+}
+
+//共享资料库中的指定路径
+func (lib *Library) share(path, shareType, key string, targets []string, perm string) error {
+	q := url.Values{"p": {path}}
+	d := url.Values{
+		"share_type": {shareType},
+		"permission": {perm},
+		key:          targets,
+	}
+
+	var respInfo struct {
+		Failed []struct {
+			Email     string
+			GroupName string `json:"group_name"`
+			ErrorMsg  string `json:"error_msg"`
+		}
+	}
+	err := lib.sharedItemsRequest("PUT", q, d, &respInfo)
+	if err != nil {
+		return err
+	}
+
+	if len(respInfo.Failed) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(respInfo.Failed))
+	for _, f := range respInfo.Failed {
+		msgs = append(msgs, fmt.Sprintf("%s%s: %s", f.Email, f.GroupName, f.ErrorMsg))
+	}
+
+	return fmt.Errorf("部分共享失败: %s", strings.Join(msgs, "; "))
+}
+
+//将资料库中的指定路径共享给用户
+//    path为"/"时表示共享整个资料库
+func (lib *Library) ShareToUsers(path string, emails []string, perm string) error {
+	return lib.share(path, ShareTypeUser, "username", emails, perm)
+}
+
+//将资料库中的指定路径共享给群组
+//    path为"/"时表示共享整个资料库
+func (lib *Library) ShareToGroups(path string, groupIDs []int, perm string) error {
+	ids := make([]string, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		ids = append(ids, strconv.Itoa(id))
+	}
+	return lib.share(path, ShareTypeGroup, "group_id", ids, perm)
+}
+
+//修改共享给用户的权限
+func (lib *Library) UpdateUserShare(path, email, perm string) error {
+	q := url.Values{"p": {path}, "share_type": {ShareTypeUser}, "username": {email}}
+	d := url.Values{"permission": {perm}}
+	return lib.sharedItemsRequest("POST", q, d, nil)
+}
+
+//修改共享给群组的权限
+func (lib *Library) UpdateGroupShare(path string, groupID int, perm string) error {
+	q := url.Values{"p": {path}, "share_type": {ShareTypeGroup}, "group_id": {strconv.Itoa(groupID)}}
+	d := url.Values{"permission": {perm}}
+	return lib.sharedItemsRequest("POST", q, d, nil)
+}
+
+//取消共享给用户
+func (lib *Library) UnshareFromUser(path, email string) error {
+	q := url.Values{"p": {path}, "share_type": {ShareTypeUser}, "username": {email}}
+	return lib.sharedItemsRequest("DELETE", q, nil, nil)
+}
+
+//取消共享给群组
+func (lib *Library) UnshareFromGroup(path string, groupID int) error {
+	q := url.Values{"p": {path}, "share_type": {ShareTypeGroup}, "group_id": {strconv.Itoa(groupID)}}
+	return lib.sharedItemsRequest("DELETE", q, nil, nil)
+}
@@ -0,0 +1,24 @@
+package seafile
+
+import (
+	"os"
+	"testing"
+)
+
+func TestListShareItems(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	lib, err := client.GetLibrary(os.Getenv("SEAFILE_REPO"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := lib.ListShareItems("/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		t.Logf("%+v", item)
+	}
+}
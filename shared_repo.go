@@ -0,0 +1,119 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+//我共享出去的资料库或文件夹
+type SharedRepo struct {
+	ShareType  string `json:"share_type"` //personal、group或public
+	RepoId     string `json:"repo_id"`
+	RepoName   string `json:"repo_name"`
+	Path       string `json:"path"`        //仅共享文件夹有效
+	FolderName string `json:"folder_name"` //仅共享文件夹有效
+	Permission string `json:"share_permission"`
+	Encrypted  bool   `json:"encrypted"`
+
+	UserEmail string `json:"user_email"` //仅share_type为personal时有效
+	UserName  string `json:"user_name"`  //仅share_type为personal时有效
+	GroupId   int    `json:"group_id"`   //仅share_type为group时有效
+	GroupName string `json:"group_name"` //仅share_type为group时有效
+}
+
+//获取共享出去的资料库或文件夹列表
+func (cli *Client) listSharedOut(uri string) ([]SharedRepo, error) {
+	resp, err := cli.apiGET(uri)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var repos []SharedRepo
+	err = decodeResponse(resp, &repos)
+	if err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
+//获取我共享出去的资料库，每个共享对象一条记录
+func (cli *Client) ListSharedOutLibraries() ([]SharedRepo, error) {
+	return cli.listSharedOut("/shared-repos/")
+}
+
+//获取我共享出去的文件夹，每个共享对象一条记录
+func (cli *Client) ListSharedOutFolders() ([]SharedRepo, error) {
+	return cli.listSharedOut("/shared-folders/")
+}
+
+//取消共享出去的资料库或文件夹
+func (cli *Client) RevokeSharedOut(item SharedRepo) error {
+	//共享文件夹通过原资料库的共享接口取消
+	if item.Path != "" && item.Path != "/" {
+		lib := &Library{Id: item.RepoId, client: cli}
+		if item.ShareType == "group" {
+			return lib.UnshareFromGroup(item.Path, item.GroupId)
+		}
+		return lib.UnshareFromUser(item.Path, item.UserEmail)
+	}
+
+	q := url.Values{"share_type": {item.ShareType}}
+	switch item.ShareType {
+	case "personal":
+		q.Set("user", item.UserEmail)
+	case "group":
+		q.Set("group_id", strconv.Itoa(item.GroupId))
+	}
+
+	resp, err := cli.apiDELETE("/shared-repos/" + item.RepoId + "/?" + q.Encode())
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//获取私人共享给我的文件夹
+//    共享的文件夹以虚拟资料库的形式出现在共享给我的资料库中
+func (cli *Client) ListSharedFolders() ([]*Library, error) {
+	libraries, err := cli.ListSharedLibraries()
+	if err != nil {
+		return nil, err
+	}
+
+	folders := []*Library{}
+	for _, lib := range libraries {
+		if lib.Virtual {
+			folders = append(folders, lib)
+		}
+	}
+
+	return folders, nil
+}
+
+//退出私人共享给我的资料库
+//    lib需要来自ListSharedLibraries，群组共享的资料库无法退出
+func (cli *Client) LeaveSharedLibrary(lib *Library) error {
+	q := url.Values{
+		"share_type": {"personal"},
+		"from":       {lib.Owner},
+	}
+
+	resp, err := cli.doRequest("DELETE", "/beshared-repos/"+lib.Id+"/?"+q.Encode(), nil, nil)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//退出私人共享给我的文件夹
+//    folder需要来自ListSharedFolders
+func (cli *Client) LeaveSharedFolder(folder *Library) error {
+	return cli.LeaveSharedLibrary(folder)
+}
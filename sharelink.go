@@ -0,0 +1,169 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+//共享链接的权限
+type SharePermission string
+
+const (
+	SharePermissionPreview  SharePermission = "preview"  //仅预览
+	SharePermissionDownload SharePermission = "download" //预览和下载
+	SharePermissionEdit     SharePermission = "edit"     //预览、下载和在线编辑
+)
+
+//共享链接的权限详情
+type ShareLinkPermissions struct {
+	CanEdit     bool `json:"can_edit"`
+	CanDownload bool `json:"can_download"`
+}
+
+//共享链接
+type ShareLink struct {
+	Token       string
+	Link        string
+	Username    string
+	RepoId      string    `json:"repo_id"`
+	RepoName    string    `json:"repo_name"`
+	Path        string    `json:"path"`
+	ObjName     string    `json:"obj_name"`
+	IsDir       bool      `json:"is_dir"`
+	ViewCnt     int       `json:"view_cnt"`
+	Ctime       time.Time `json:"ctime"`
+	ExpireDate  string    `json:"expire_date"` //为空表示永不过期
+	IsExpired   bool      `json:"is_expired"`
+	Permissions ShareLinkPermissions
+
+	client *Client `json:"-"`
+}
+
+//权限对应的权限详情
+func (perm SharePermission) permissions() ShareLinkPermissions {
+	switch perm {
+	case SharePermissionEdit:
+		return ShareLinkPermissions{CanEdit: true, CanDownload: true}
+	case SharePermissionPreview:
+		return ShareLinkPermissions{}
+	default:
+		return ShareLinkPermissions{CanDownload: true}
+	}
+}
+
+//共享链接的权限
+func (link *ShareLink) Permission() SharePermission {
+	switch {
+	case link.Permissions.CanEdit:
+		return SharePermissionEdit
+	case link.Permissions.CanDownload:
+		return SharePermissionDownload
+	default:
+		return SharePermissionPreview
+	}
+}
+
+//为资料库中的文件或文件夹创建共享链接
+//    password为空表示不设置密码，expireDays为0表示永不过期
+func (lib *Library) CreateShareLink(path, password string, expireDays int, perm SharePermission) (*ShareLink, error) {
+	req := struct {
+		RepoId      string               `json:"repo_id"`
+		Path        string               `json:"path"`
+		Password    string               `json:"password,omitempty"`
+		ExpireDays  int                  `json:"expire_days,omitempty"`
+		Permissions ShareLinkPermissions `json:"permissions"`
+	}{lib.Id, path, password, expireDays, perm.permissions()}
+
+	resp, err := lib.client.apiPOSTJSON("/share-links/", req)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var link ShareLink
+	err = decodeResponse(resp, &link)
+	if err != nil {
+		return nil, err
+	}
+
+	link.client = lib.client
+
+	return &link, nil
+}
+
+//获取共享链接列表
+func (cli *Client) listShareLinks(q url.Values) ([]*ShareLink, error) {
+	uri := "/share-links/"
+	if len(q) > 0 {
+		uri += "?" + q.Encode()
+	}
+
+	resp, err := cli.apiGET(uri)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var links []*ShareLink
+	err = decodeResponse(resp, &links)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, link := range links {
+		link.client = cli
+	}
+
+	return links, nil
+}
+
+//获取当前用户创建的所有共享链接
+func (cli *Client) ListShareLinks() ([]*ShareLink, error) {
+	return cli.listShareLinks(nil)
+}
+
+//获取资料库中指定路径的共享链接
+//    path为空时获取整个资料库中的共享链接
+func (lib *Library) ListShareLinks(path string) ([]*ShareLink, error) {
+	q := url.Values{"repo_id": {lib.Id}}
+	if path != "" {
+		q.Set("path", path)
+	}
+	return lib.client.listShareLinks(q)
+}
+
+//根据Token获取共享链接
+func (cli *Client) GetShareLink(token string) (*ShareLink, error) {
+	resp, err := cli.apiGET("/share-links/" + token + "/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var link ShareLink
+	err = decodeResponse(resp, &link)
+	if err != nil {
+		return nil, err
+	}
+
+	link.client = cli
+
+	return &link, nil
+}
+
+//根据Token删除共享链接
+func (cli *Client) DeleteShareLink(token string) error {
+	resp, err := cli.apiDELETE("/share-links/" + token + "/")
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//删除共享链接
+func (link *ShareLink) Delete() error {
+	return link.client.DeleteShareLink(link.Token)
+}
@@ -0,0 +1,27 @@
+package seafile
+
+import (
+	"os"
+	"testing"
+)
+
+func TestShareLink(t *testing.T) {
+	client := New(os.Getenv("SEAFILE_HOST"), os.Getenv("SEAFILE_TOKEN"))
+
+	lib, err := client.GetLibrary(os.Getenv("SEAFILE_REPO"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link, err := lib.CreateShareLink(os.Getenv("SEAFILE_FILE"), "", 1, SharePermissionPreview)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("%+v", link)
+
+	err = link.Delete()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,129 @@
+package seafile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+//列出资料库在指定提交（快照）时，指定目录的内容
+func (lib *Library) ListSnapshotDir(commitID, path string) ([]DirectoryEntry, error) {
+	if path == "" {
+		path = "/"
+	}
+
+	q := url.Values{"path": {path}}
+	uri := fmt.Sprintf("/repos/%s/commits/%s/dir/?%s", lib.Id, commitID, q.Encode())
+	resp, err := lib.client.apiGET(uri)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	var respInfo struct {
+		DirentList []struct {
+			Type  string
+			Name  string
+			ObjId string `json:"obj_id"`
+			Size  int
+			Mtime int
+		} `json:"dirent_list"`
+	}
+	err = json.Unmarshal(b, &respInfo)
+	if err != nil {
+		return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	entries := make([]DirectoryEntry, 0, len(respInfo.DirentList))
+	for _, d := range respInfo.DirentList {
+		entries = append(entries, DirectoryEntry{
+			Id:        d.ObjId,
+			Type:      d.Type,
+			Name:      d.Name,
+			Size:      d.Size,
+			Mtime:     d.Mtime,
+			ParentDir: path,
+		})
+	}
+
+	return entries, nil
+}
+
+//获取文件在指定提交时版本的下载链接
+func (cli *Client) fileRevisionLink(repoID, path, commitID string) (string, error) {
+	q := url.Values{"p": {path}, "commit_id": {commitID}}
+	resp, err := cli.doRequest("GET", "/repos/"+repoID+"/file/revision/?"+q.Encode(), nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	var link string
+	err = json.Unmarshal(b, &link)
+	if err != nil {
+		return "", fmt.Errorf("解析错误:%s %s", err, string(b))
+	}
+
+	return link, nil
+}
+
+//打开文件在指定提交时的版本
+func (cli *Client) openFileRevision(repoID, path, commitID string) (io.ReadCloser, error) {
+	link, err := cli.fileRevisionLink(repoID, path, commitID)
+	if err != nil {
+		return nil, fmt.Errorf("请求下载地址错误:%s", err)
+	}
+
+	resp, err := http.Get(link)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件内容错误: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	return resp.Body, nil
+}
+
+//从指定提交（快照）中下载文件
+//    返回的内容需要调用者关闭
+func (lib *Library) DownloadFromSnapshot(commitID, path string) (io.ReadCloser, error) {
+	return lib.client.openFileRevision(lib.Id, path, commitID)
+}
+
+//将资料库整体恢复到指定提交（快照）时的状态
+//    恢复操作本身会产生一个新的提交，不会丢失之后的历史
+func (lib *Library) RevertToCommit(commitID string) error {
+	uri := fmt.Sprintf("/repos/%s/commits/%s/revert/", lib.Id, commitID)
+	resp, err := lib.client.apiPOST(uri, nil, nil)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
@@ -0,0 +1,95 @@
+package seafile
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//星标文件或文件夹
+type StarredItem struct {
+	RepoId        string    `json:"repo_id"`
+	RepoName      string    `json:"repo_name"`
+	RepoEncrypted bool      `json:"repo_encrypted"`
+	IsDir         bool      `json:"is_dir"`
+	Path          string    `json:"path"`
+	ObjName       string    `json:"obj_name"`
+	Mtime         time.Time `json:"mtime"`
+	UserEmail     string    `json:"user_email"`
+	UserName      string    `json:"user_name"`
+}
+
+//所在目录
+//    文件夹的路径可能以/结尾，需要先去掉
+func (item *StarredItem) ParentDir() string {
+	p := item.Path
+	if p != "/" {
+		p = strings.TrimSuffix(p, "/")
+	}
+	return filepath.Dir(p)
+}
+
+//判断是否为资料库repoID中parentDir目录下的entry
+//    用于将星标与ListDirectoryEntries的结果关联
+func (item *StarredItem) Match(repoID, parentDir string, entry DirectoryEntry) bool {
+	if item.RepoId != repoID {
+		return false
+	}
+
+	if item.IsDir != (entry.Type == "dir") {
+		return false
+	}
+
+	return filepath.Join("/", parentDir, entry.Name) == filepath.Join("/", item.Path)
+}
+
+//获取当前用户的所有星标
+func (cli *Client) StarredItems() ([]*StarredItem, error) {
+	resp, err := cli.apiGET("/starred-items/")
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		StarredItemList []*StarredItem `json:"starred_item_list"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return respInfo.StarredItemList, nil
+}
+
+//为资料库中的文件或文件夹添加星标
+func (lib *Library) Star(path string) error {
+	d := url.Values{
+		"repo_id": {lib.Id},
+		"path":    {path},
+	}
+	resp, err := lib.client.apiPOSTForm("/starred-items/", d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//取消资料库中文件或文件夹的星标
+func (lib *Library) Unstar(path string) error {
+	q := url.Values{
+		"repo_id": {lib.Id},
+		"path":    {path},
+	}
+	resp, err := lib.client.apiDELETE("/starred-items/?" + q.Encode())
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
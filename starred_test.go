@@ -0,0 +1,21 @@
+package seafile
+
+import "testing"
+
+func TestStarredItemParentDir(t *testing.T) {
+	cases := map[string]string{
+		"/a/b/":   "/a",
+		"/a/b":    "/a",
+		"/a.txt":  "/",
+		"/a/":     "/",
+		"/":       "/",
+		"/a/b.md": "/a",
+	}
+
+	for p, want := range cases {
+		item := &StarredItem{Path: p}
+		if got := item.ParentDir(); got != want {
+			t.Errorf("%s的所在目录为%s，期望%s", p, got, want)
+		}
+	}
+}
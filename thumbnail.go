@@ -0,0 +1,113 @@
+package seafile
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+//获取图片文件的缩略图
+//    size为缩略图的边长（像素），返回的内容需要调用者关闭
+func (lib *Library) Thumbnail(path string, size int) (io.ReadCloser, error) {
+	q := url.Values{"p": {path}, "size": {strconv.Itoa(size)}}
+	resp, err := lib.doRequest("GET", "/thumbnail/?"+q.Encode(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	return resp.Body, nil
+}
+
+//文件在网页端的预览地址，支持Office、PDF等格式的在线预览
+//    访问该地址需要在浏览器中登录
+func (file *File) PreviewURL() string {
+	u := url.URL{Path: "/lib/" + file.RepoId + "/file" + file.Path()}
+	return file.repo.client.Addr + u.EscapedPath()
+}
+
+//缩略图的本地磁盘缓存
+//    以文件ID和尺寸作为缓存键，文件内容变化后ID随之变化，因此不会读到过期的缩略图
+type ThumbnailCache struct {
+	Dir string
+}
+
+//新建缩略图缓存，目录不存在时自动创建
+func NewThumbnailCache(dir string) (*ThumbnailCache, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("创建缓存目录失败: %s", err)
+	}
+
+	return &ThumbnailCache{Dir: dir}, nil
+}
+
+//获取缩略图，缓存中不存在时从服务器获取并写入缓存
+//    fileID为文件的ID（File.Id或DirectoryEntry.Id），返回的内容需要调用者关闭
+func (cache *ThumbnailCache) Thumbnail(lib *Library, path, fileID string, size int) (io.ReadCloser, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("文件ID为空")
+	}
+
+	cachePath := filepath.Join(cache.Dir, cacheKey(lib.Id, fileID)+"-"+strconv.Itoa(size))
+
+	f, err := os.Open(cachePath)
+	if err == nil {
+		return f, nil
+	}
+
+	r, err := lib.Thumbnail(path, size)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	//先写入临时文件，避免并发读取到不完整的缓存
+	tmp, err := ioutil.TempFile(cache.Dir, "tmp-")
+	if err != nil {
+		return nil, fmt.Errorf("创建缓存文件失败: %s", err)
+	}
+
+	_, err = io.Copy(tmp, r)
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("写入缓存文件失败: %s", err)
+	}
+
+	err = os.Rename(tmp.Name(), cachePath)
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("写入缓存文件失败: %s", err)
+	}
+
+	return os.Open(cachePath)
+}
+
+//缓存文件名，对资料库ID和文件ID取哈希，避免文件ID中的特殊字符影响缓存路径
+func cacheKey(libraryID, fileID string) string {
+	sum := sha1.Sum([]byte(libraryID + "/" + fileID))
+	return hex.EncodeToString(sum[:])
+}
+
+//获取目录项的缩略图
+func (cache *ThumbnailCache) EntryThumbnail(lib *Library, parentDir string, entry DirectoryEntry, size int) (io.ReadCloser, error) {
+	return cache.Thumbnail(lib, filepath.Join(parentDir, entry.Name), entry.Id, size)
+}
+
+//获取文件的缩略图
+func (cache *ThumbnailCache) FileThumbnail(file *File, size int) (io.ReadCloser, error) {
+	return cache.Thumbnail(file.repo.Library(), file.Path(), file.Id, size)
+}
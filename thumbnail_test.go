@@ -0,0 +1,52 @@
+package seafile
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestThumbnailCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("thumbnail"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "seafile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewThumbnailCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lib := &Library{Id: "repo", client: New(server.URL, "token")}
+
+	for i := 0; i < 2; i++ {
+		r, err := cache.Thumbnail(lib, "/a.png", "fileid", 48)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != "thumbnail" {
+			t.Fatalf("缩略图内容错误: %s", string(b))
+		}
+	}
+
+	if requests != 1 {
+		t.Fatalf("期望请求服务器1次，实际%d次", requests)
+	}
+}
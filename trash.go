@@ -0,0 +1,155 @@
+package seafile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+//回收站中的项目
+type TrashItem struct {
+	ObjId       string    `json:"obj_id"`
+	ObjName     string    `json:"obj_name"`
+	ParentDir   string    `json:"parent_dir"`
+	CommitId    string    `json:"commit_id"` //删除前最后一次包含该项目的提交
+	IsDir       bool      `json:"is_dir"`
+	Size        int64     `json:"size"`
+	ScanStat    string    `json:"scan_stat"`
+	DeletedTime time.Time `json:"deleted_time"`
+
+	library *Library `json:"-"`
+}
+
+//项目完整路径
+func (item *TrashItem) Path() string {
+	return filepath.Join(item.ParentDir, item.ObjName)
+}
+
+//从回收站恢复该项目
+func (item *TrashItem) Restore() error {
+	repo := item.library.repo()
+	if item.IsDir {
+		return repo.revertDir(item.Path(), item.CommitId)
+	}
+	return repo.revertFile(item.Path(), item.CommitId)
+}
+
+//获取回收站中指定目录下被删除的项目（单页）
+//    scanStat为上一页返回的扫描位置，首次请求传空字符串
+//    返回值依次为: 项目列表、是否还有更多、下一页的扫描位置
+func (lib *Library) TrashPage(path, scanStat string, perPage int) ([]*TrashItem, bool, string, error) {
+	if path == "" {
+		path = "/"
+	}
+
+	q := url.Values{"path": {path}}
+	if scanStat != "" {
+		q.Set("scan_stat", scanStat)
+	}
+	if perPage > 0 {
+		q.Set("per_page", strconv.Itoa(perPage))
+	}
+
+	resp, err := lib.client.apiGET("/repos/" + lib.Id + "/trash/?" + q.Encode())
+	if err != nil {
+		return nil, false, "", fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, "", fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, "", fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	var respInfo struct {
+		Data     []*TrashItem
+		More     bool
+		ScanStat string `json:"scan_stat"`
+	}
+	err = json.Unmarshal(b, &respInfo)
+	if err != nil {
+		return nil, false, "", fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	for _, item := range respInfo.Data {
+		item.library = lib
+	}
+
+	return respInfo.Data, respInfo.More, respInfo.ScanStat, nil
+}
+
+//获取回收站中指定目录下所有被删除的项目
+func (lib *Library) Trash(path string) ([]*TrashItem, error) {
+	var items []*TrashItem
+
+	scanStat := ""
+	for {
+		page, more, next, err := lib.TrashPage(path, scanStat, 100)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if !more || next == "" {
+			break
+		}
+		scanStat = next
+	}
+
+	return items, nil
+}
+
+//从回收站恢复指定路径的文件或文件夹
+//    commitID为TrashItem中的CommitId
+func (lib *Library) RestoreFromTrash(path, commitID string) error {
+	//根据删除前的快照判断是文件还是文件夹
+	entries, err := lib.ListSnapshotDir(commitID, filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("获取快照内容失败: %s", err)
+	}
+
+	name := filepath.Base(path)
+	for _, entry := range entries {
+		if entry.Name != name {
+			continue
+		}
+
+		item := TrashItem{
+			ObjName:   name,
+			ParentDir: filepath.Dir(path),
+			CommitId:  commitID,
+			IsDir:     entry.Type == "dir",
+			library:   lib,
+		}
+		return item.Restore()
+	}
+
+	return fmt.Errorf("快照中未找到: %s", path)
+}
+
+//清空回收站
+//    keepDays为保留最近多少天内删除的项目，0表示全部清空
+func (lib *Library) CleanTrash(keepDays int) error {
+	d := url.Values{"keep_days": {strconv.Itoa(keepDays)}}
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	body := bytes.NewBufferString(d.Encode())
+
+	resp, err := lib.client.apiRequestV2p1("DELETE", "/repos/"+lib.Id+"/trash/", header, body)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
@@ -0,0 +1,179 @@
+package seafile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//上传链接
+//    持有链接的人无需账号即可向对应的文件夹上传文件
+type SharedUploadLink struct {
+	Token      string
+	Link       string
+	Username   string
+	RepoId     string    `json:"repo_id"`
+	RepoName   string    `json:"repo_name"`
+	Path       string    `json:"path"`
+	ObjName    string    `json:"obj_name"`
+	ViewCnt    int       `json:"view_cnt"`
+	Ctime      time.Time `json:"ctime"`
+	ExpireDate string    `json:"expire_date"` //为空表示永不过期
+	IsExpired  bool      `json:"is_expired"`
+
+	client *Client `json:"-"`
+}
+
+//为资料库中的文件夹创建上传链接
+//    password为空表示不设置密码，expireDays为0表示永不过期
+func (lib *Library) CreateUploadLink(path, password string, expireDays int) (*SharedUploadLink, error) {
+	d := url.Values{
+		"repo_id": {lib.Id},
+		"path":    {path},
+	}
+	if password != "" {
+		d.Set("password", password)
+	}
+	if expireDays > 0 {
+		d.Set("expire_days", strconv.Itoa(expireDays))
+	}
+
+	resp, err := lib.client.apiPOSTForm("/upload-links/", d)
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var link SharedUploadLink
+	err = decodeResponse(resp, &link)
+	if err != nil {
+		return nil, err
+	}
+
+	link.client = lib.client
+
+	return &link, nil
+}
+
+//获取资料库中指定路径的上传链接
+//    path为空时获取整个资料库中的上传链接
+func (lib *Library) ListUploadLinks(path string) ([]*SharedUploadLink, error) {
+	q := url.Values{"repo_id": {lib.Id}}
+	if path != "" {
+		q.Set("path", path)
+	}
+
+	resp, err := lib.client.apiGET("/upload-links/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var links []*SharedUploadLink
+	err = decodeResponse(resp, &links)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, link := range links {
+		link.client = lib.client
+	}
+
+	return links, nil
+}
+
+//根据Token删除上传链接
+func (cli *Client) DeleteUploadLink(token string) error {
+	resp, err := cli.apiDELETE("/upload-links/" + token + "/")
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//删除上传链接
+func (link *SharedUploadLink) Delete() error {
+	return link.client.DeleteUploadLink(link.Token)
+}
+
+//通过上传链接匿名上传文件，无需账号和Token
+//    不支持设置了密码的上传链接
+type UploadLinkUploader struct {
+	Addr      string //Seafile服务器地址
+	Token     string //上传链接的Token
+	ParentDir string //上传链接对应的文件夹路径，即SharedUploadLink.Path
+}
+
+//新建匿名上传器
+func NewUploadLinkUploader(addr, token, parentDir string) *UploadLinkUploader {
+	return &UploadLinkUploader{
+		Addr:      strings.TrimSuffix(addr, "/"),
+		Token:     token,
+		ParentDir: parentDir,
+	}
+}
+
+//获取文件服务器的上传地址
+func (u *UploadLinkUploader) uploadURL() (string, error) {
+	resp, err := http.Get(u.Addr + "/api/v2.1/upload-links/" + u.Token + "/upload/")
+	if err != nil {
+		return "", fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	var respInfo struct {
+		UploadLink string `json:"upload_link"`
+	}
+	err = decodeResponse(resp, &respInfo)
+	if err != nil {
+		return "", err
+	}
+
+	return respInfo.UploadLink, nil
+}
+
+//上传文件内容到上传链接对应文件夹下的dir子目录
+//    fileContentMap的key是文件名，value是文件内容
+//    dir为空时直接上传到上传链接对应的文件夹
+func (u *UploadLinkUploader) Upload(dir string, fileContentMap map[string][]byte) error {
+	fields := map[string]string{"parent_dir": u.ParentDir}
+	if dir != "" {
+		fields["relative_path"] = dir
+	}
+
+	body, contentType, err := newUploadBody(fileContentMap, fields)
+	if err != nil {
+		return err
+	}
+
+	link, err := u.uploadURL()
+	if err != nil {
+		return fmt.Errorf("获取上传地址错误:%s", err)
+	}
+
+	resp, err := http.Post(link+"?ret-json=1", contentType, body)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	respInfo := []DirectoryEntry{}
+	err = json.NewDecoder(resp.Body).Decode(&respInfo)
+	if err != nil {
+		return fmt.Errorf("解析错误:%s", err)
+	}
+
+	return nil
+}
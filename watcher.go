@@ -0,0 +1,321 @@
+package seafile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+//变更事件类型
+type WatchEventType string
+
+const (
+	WatchCreated  WatchEventType = "created"
+	WatchModified WatchEventType = "modified"
+	WatchDeleted  WatchEventType = "deleted"
+	WatchRenamed  WatchEventType = "renamed" //包括重命名和移动
+)
+
+//变更事件
+type WatchEvent struct {
+	Type        WatchEventType
+	LibraryId   string
+	LibraryName string
+	Path        string
+	OldPath     string //仅WatchRenamed有效
+	IsDir       bool
+	Commit      *LibraryCommit
+}
+
+//检查点，用于保存每个资料库已处理到的提交，重启后不会重复产生事件
+type Checkpoint interface {
+	Load() (map[string]string, error) //key为资料库ID，value为提交ID
+	Save(map[string]string) error
+}
+
+//保存在本地JSON文件中的检查点
+type FileCheckpoint struct {
+	Path string
+}
+
+//读取检查点，文件不存在时返回空的检查点
+func (cp *FileCheckpoint) Load() (map[string]string, error) {
+	heads := map[string]string{}
+
+	b, err := ioutil.ReadFile(cp.Path)
+	if os.IsNotExist(err) {
+		return heads, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取检查点失败: %s", err)
+	}
+
+	err = json.Unmarshal(b, &heads)
+	if err != nil {
+		return nil, fmt.Errorf("解析检查点失败: %s", err)
+	}
+
+	return heads, nil
+}
+
+//保存检查点，先写入临时文件再替换，避免写入中断导致文件损坏
+func (cp *FileCheckpoint) Save(heads map[string]string) error {
+	b, err := json.Marshal(heads)
+	if err != nil {
+		return err
+	}
+
+	tmp := cp.Path + ".tmp"
+	err = ioutil.WriteFile(tmp, b, 0644)
+	if err != nil {
+		return fmt.Errorf("写入检查点失败: %s", err)
+	}
+
+	return os.Rename(tmp, cp.Path)
+}
+
+//通过轮询资料库的HeadCommitId监视资料库的变更
+//  用法:
+//    w := cli.NewWatcher(&FileCheckpoint{Path: "heads.json"}, libID)
+//    err := w.Start()
+//    for event := range w.Events {
+//    }
+//  Events没有缓冲，每个提交的事件全部被接收后才更新检查点，
+//  Stop时正在处理的提交会在下次Start后重新产生事件，因此同一事件可能收到多次
+type Watcher struct {
+	Interval time.Duration   //轮询间隔，小于等于0时使用默认的一分钟
+	Events   chan WatchEvent //变更事件，Stop后关闭
+	Errors   chan error      //轮询过程中的错误，未及时读取的错误会被丢弃
+
+	client     *Client
+	libraryIDs []string
+	checkpoint Checkpoint
+	heads      map[string]string
+	stop       chan struct{}
+	mu         sync.Mutex //保护stop和stopped
+	stopped    bool
+	wg         sync.WaitGroup
+}
+
+//新建监视器
+//    checkpoint为nil时不保存检查点，libraryIDs为空时监视所有可见的资料库
+func (cli *Client) NewWatcher(checkpoint Checkpoint, libraryIDs ...string) *Watcher {
+	return &Watcher{
+		Interval:   time.Minute,
+		Events:     make(chan WatchEvent),
+		Errors:     make(chan error, 10),
+		client:     cli,
+		libraryIDs: libraryIDs,
+		checkpoint: checkpoint,
+		heads:      map[string]string{},
+	}
+}
+
+//开始监视
+//    首次监视的资料库只记录当前提交，不会产生历史事件
+func (w *Watcher) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return fmt.Errorf("监视器已停止")
+	}
+	if w.stop != nil {
+		return fmt.Errorf("监视器已启动")
+	}
+
+	if w.checkpoint != nil {
+		heads, err := w.checkpoint.Load()
+		if err != nil {
+			return err
+		}
+		w.heads = heads
+	}
+
+	w.stop = make(chan struct{})
+	w.wg.Add(1)
+	go w.run()
+
+	return nil
+}
+
+//停止监视，并关闭Events和Errors
+//    可以重复调用，未Start时也可以调用，停止后不能再次Start
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return
+	}
+	w.stopped = true
+	stop := w.stop
+	w.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		w.wg.Wait()
+	}
+	close(w.Events)
+	close(w.Errors)
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.poll()
+
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+//发送错误，没有接收者时丢弃
+func (w *Watcher) sendError(err error) {
+	select {
+	case w.Errors <- err:
+	default:
+	}
+}
+
+//检查所有资料库的变更
+func (w *Watcher) poll() {
+	libraries, err := w.client.ListAllLibraries()
+	if err != nil {
+		w.sendError(fmt.Errorf("获取资料库列表失败: %s", err))
+		return
+	}
+
+	watched := map[string]bool{}
+	for _, id := range w.libraryIDs {
+		watched[id] = true
+	}
+
+	for _, lib := range libraries {
+		if len(watched) > 0 && !watched[lib.Id] {
+			continue
+		}
+
+		last, found := w.heads[lib.Id]
+		if found && last == lib.HeadCommitId {
+			continue
+		}
+
+		if !found {
+			w.advance(lib.Id, lib.HeadCommitId)
+			continue
+		}
+
+		err = w.emitChanges(lib, last)
+		if err == errWatcherStopped {
+			return
+		}
+		if err != nil {
+			//下次轮询时从已处理的提交继续
+			w.sendError(fmt.Errorf("获取资料库%s的变更失败: %s", lib.Name, err))
+		}
+	}
+}
+
+//记录资料库已处理到的提交并保存检查点
+func (w *Watcher) advance(libraryID, commitID string) {
+	w.heads[libraryID] = commitID
+
+	if w.checkpoint != nil {
+		err := w.checkpoint.Save(w.heads)
+		if err != nil {
+			w.sendError(err)
+		}
+	}
+}
+
+var errWatcherStopped = fmt.Errorf("监视器已停止")
+
+//产生上次处理的提交之后所有提交的事件
+func (w *Watcher) emitChanges(lib *Library, last string) error {
+	var commits []*LibraryCommit
+
+	it := lib.IterateHistory(50)
+	found := false
+	for it.Next() {
+		if it.Commit().Id == last {
+			found = true
+			break
+		}
+		commits = append(commits, it.Commit())
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	//上次处理的提交可能已被清理，此时跳过中间的变更，避免重放整个历史
+	if !found {
+		w.sendError(fmt.Errorf("资料库%s的历史中未找到提交%s，已跳过", lib.Name, last))
+		w.advance(lib.Id, lib.HeadCommitId)
+		return nil
+	}
+
+	//按时间由旧到新产生事件
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+
+		//合并提交的变更已包含在被合并的提交中
+		if commit.SecondParentId != "" {
+			w.advance(lib.Id, commit.Id)
+			continue
+		}
+
+		changes, err := commit.Changes()
+		if err != nil {
+			return err
+		}
+
+		for _, activity := range commit.Activities(changes) {
+			event := WatchEvent{
+				LibraryId:   lib.Id,
+				LibraryName: lib.Name,
+				Path:        activity.Path,
+				OldPath:     activity.OldPath,
+				IsDir:       activity.ObjType == "dir",
+				Commit:      commit,
+			}
+
+			switch activity.OpType {
+			case ActivityCreate:
+				event.Type = WatchCreated
+			case ActivityEdit:
+				event.Type = WatchModified
+			case ActivityDelete:
+				event.Type = WatchDeleted
+			default:
+				event.Type = WatchRenamed
+			}
+
+			select {
+			case w.Events <- event:
+			case <-w.stop:
+				return errWatcherStopped
+			}
+		}
+
+		//该提交的事件已全部被接收
+		w.advance(lib.Id, commit.Id)
+	}
+
+	return nil
+}
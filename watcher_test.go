@@ -0,0 +1,248 @@
+package seafile
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seafile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cp := &FileCheckpoint{Path: filepath.Join(dir, "heads.json")}
+
+	heads, err := cp.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heads) != 0 {
+		t.Fatalf("期望空的检查点，实际为%v", heads)
+	}
+
+	err = cp.Save(map[string]string{"repo": "commit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	heads, err = cp.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if heads["repo"] != "commit" {
+		t.Fatalf("检查点内容错误: %v", heads)
+	}
+}
+
+//保存在内存中的检查点，记录每次保存时的提交
+type memoryCheckpoint struct {
+	mu    sync.Mutex
+	heads map[string]string
+	saves []string
+}
+
+func (cp *memoryCheckpoint) Load() (map[string]string, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	heads := map[string]string{}
+	for k, v := range cp.heads {
+		heads[k] = v
+	}
+	return heads, nil
+}
+
+func (cp *memoryCheckpoint) Save(heads map[string]string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.heads = map[string]string{}
+	for k, v := range heads {
+		cp.heads[k] = v
+	}
+	cp.saves = append(cp.saves, heads["repo"])
+	return nil
+}
+
+func (cp *memoryCheckpoint) Saves() []string {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return append([]string(nil), cp.saves...)
+}
+
+//模拟资料库repo的提交历史：c0 <- c1(新建/a.txt) <- c2(合并) <- c3(修改/a.txt，删除/b.txt)
+func newWatcherServer(historyStatus int) *httptest.Server {
+	commits := []map[string]interface{}{
+		{"id": "c3", "ctime": 1003, "repo_id": "repo", "parent_id": "c2"},
+		{"id": "c2", "ctime": 1002, "repo_id": "repo", "parent_id": "c1", "second_parent_id": "x1"},
+		{"id": "c1", "ctime": 1001, "repo_id": "repo", "parent_id": "c0"},
+		{"id": "c0", "ctime": 1000, "repo_id": "repo"},
+	}
+
+	changes := map[string]LibraryCommitChanges{
+		"c1": {AddedFiles: []string{"a.txt"}},
+		"c2": {AddedFiles: []string{"a.txt"}},
+		"c3": {ModifiedFiles: []string{"a.txt"}, DeletedFiles: []string{"b.txt"}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/repos/":
+			json.NewEncoder(w).Encode([]map[string]string{{"id": "repo", "name": "lib", "head_commit_id": "c3"}})
+		case "/api2/repos/repo/history/":
+			if historyStatus != http.StatusOK {
+				http.Error(w, `{"error_msg": "Permission denied."}`, historyStatus)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"commits": commits, "page_next": false})
+		case "/api2/repo_history_changes/repo/":
+			json.NewEncoder(w).Encode(changes[r.URL.Query().Get("commit_id")])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func receiveEvent(t *testing.T, w *Watcher) WatchEvent {
+	select {
+	case event := <-w.Events:
+		return event
+	case err := <-w.Errors:
+		t.Fatalf("监视出错: %s", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待事件超时")
+	}
+	return WatchEvent{}
+}
+
+func stopWithin(t *testing.T, w *Watcher, d time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		w.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatal("Stop未能及时返回")
+	}
+}
+
+func TestWatcherEvents(t *testing.T) {
+	server := newWatcherServer(http.StatusOK)
+	defer server.Close()
+
+	cp := &memoryCheckpoint{heads: map[string]string{"repo": "c0"}}
+	w := New(server.URL, "token").NewWatcher(cp)
+	w.Interval = time.Hour
+	err := w.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//按时间由旧到新产生事件，合并提交c2被跳过
+	event := receiveEvent(t, w)
+	if event.Type != WatchCreated || event.Path != "/a.txt" || event.Commit.Id != "c1" {
+		t.Fatalf("第1个事件错误: %s %s", event.Type, event.Path)
+	}
+
+	event = receiveEvent(t, w)
+	if event.Type != WatchModified || event.Path != "/a.txt" || event.Commit.Id != "c3" {
+		t.Fatalf("第2个事件错误: %s %s", event.Type, event.Path)
+	}
+
+	//c3的事件尚未全部被接收，检查点只能推进到c2
+	saves := cp.Saves()
+	if strings.Join(saves, ",") != "c1,c2" {
+		t.Fatalf("检查点推进错误: %v", saves)
+	}
+
+	//监视器阻塞在发送c3的第2个事件时停止
+	stopWithin(t, w, 5*time.Second)
+
+	if _, ok := <-w.Events; ok {
+		t.Fatal("停止后Events应被关闭")
+	}
+
+	heads, _ := cp.Load()
+	if heads["repo"] != "c2" {
+		t.Fatalf("停止后检查点应保留在c2: %v", heads)
+	}
+
+	//重新启动后重新产生c3的事件
+	w = New(server.URL, "token").NewWatcher(cp)
+	w.Interval = time.Hour
+	err = w.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	var paths []string
+	for i := 0; i < 2; i++ {
+		event = receiveEvent(t, w)
+		paths = append(paths, string(event.Type)+" "+event.Path)
+	}
+	if strings.Join(paths, ",") != "modified /a.txt,deleted /b.txt" {
+		t.Fatalf("重启后的事件错误: %v", paths)
+	}
+
+	for i := 0; i < 50; i++ {
+		heads, _ = cp.Load()
+		if heads["repo"] == "c3" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("事件全部被接收后检查点应推进到c3: %v", heads)
+}
+
+func TestWatcherHistoryError(t *testing.T) {
+	server := newWatcherServer(http.StatusForbidden)
+	defer server.Close()
+
+	cp := &memoryCheckpoint{heads: map[string]string{"repo": "c0"}}
+	w := New(server.URL, "token").NewWatcher(cp)
+	w.Interval = time.Hour
+	err := w.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err = <-w.Errors:
+	case event := <-w.Events:
+		t.Fatalf("获取历史失败时不应产生事件: %+v", event)
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待错误超时")
+	}
+
+	stopWithin(t, w, 5*time.Second)
+
+	if saves := cp.Saves(); len(saves) != 0 {
+		t.Fatalf("获取历史失败时不应推进检查点: %v", saves)
+	}
+}
+
+func TestWatcherStop(t *testing.T) {
+	w := New("http://127.0.0.1:1", "token").NewWatcher(nil)
+
+	//未启动时停止，重复停止
+	stopWithin(t, w, time.Second)
+	stopWithin(t, w, time.Second)
+
+	if err := w.Start(); err == nil {
+		t.Fatal("停止后不应能再次启动")
+	}
+}
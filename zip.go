@@ -0,0 +1,191 @@
+package seafile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//服务器端打包下载任务
+type ZipTask struct {
+	Token string
+
+	library *Library
+}
+
+//打包任务的进度
+type ZipProgress struct {
+	Zipped       int
+	Total        int
+	Failed       int
+	FailedReason string `json:"failed_reason"`
+	Canceled     int
+}
+
+//创建打包任务，将parentDir下的names打包为一个zip文件
+//    names为空时打包整个parentDir
+func (lib *Library) CreateZipTask(parentDir string, names []string) (*ZipTask, error) {
+	q := url.Values{"parent_dir": {parentDir}}
+	for _, name := range names {
+		q.Add("dirents", name)
+	}
+
+	resp, err := lib.client.apiGET("/repos/" + lib.Id + "/zip-task/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	var respInfo struct {
+		ZipToken string `json:"zip_token"`
+	}
+	err = json.Unmarshal(b, &respInfo)
+	if err != nil {
+		return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	task := &ZipTask{
+		Token:   respInfo.ZipToken,
+		library: lib,
+	}
+
+	return task, nil
+}
+
+//查询打包进度
+func (task *ZipTask) Progress() (*ZipProgress, error) {
+	q := url.Values{"token": {task.Token}}
+	resp, err := task.library.client.apiGET("/query-zip-progress/?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取错误:%s %s", resp.Status, err)
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	var progress ZipProgress
+	err = json.Unmarshal(b, &progress)
+	if err != nil {
+		return nil, fmt.Errorf("解析错误: %s %s", err, string(b))
+	}
+
+	return &progress, nil
+}
+
+//等待打包完成，每隔interval查询一次进度
+//    interval小于等于0时使用默认的一秒，timeout为0时不限制等待时间，超时返回错误
+func (task *ZipTask) Wait(interval, timeout time.Duration) (*ZipProgress, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		progress, err := task.Progress()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case progress.Canceled > 0:
+			return progress, fmt.Errorf("打包已取消")
+		case progress.Failed > 0:
+			return progress, fmt.Errorf("打包失败: %s", progress.FailedReason)
+		case progress.Zipped >= progress.Total:
+			return progress, nil
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return progress, fmt.Errorf("等待打包超时，已完成%d/%d", progress.Zipped, progress.Total)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+//打开打包好的zip文件
+//    需要在打包完成后调用，返回的内容需要调用者关闭
+func (task *ZipTask) Open() (io.ReadCloser, error) {
+	root := task.library.fileServerRoot()
+
+	resp, err := http.Get(root + "/zip/" + task.Token)
+	if err != nil {
+		return nil, fmt.Errorf("下载错误:%s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("[%s] %s", resp.Status, string(b))
+	}
+
+	return resp.Body, nil
+}
+
+//取消打包任务
+func (task *ZipTask) Cancel() error {
+	d := url.Values{"token": {task.Token}}
+	resp, err := task.library.client.apiPOSTForm("/cancel-zip-task/", d)
+	if err != nil {
+		return fmt.Errorf("请求错误:%s", err)
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
+
+//文件服务器的根地址
+//    优先使用Client.FileServerRoot，未设置时使用默认的 服务器地址/seafhttp
+func (lib *Library) fileServerRoot() string {
+	if lib.client.FileServerRoot != "" {
+		return strings.TrimSuffix(lib.client.FileServerRoot, "/")
+	}
+
+	return lib.client.Addr + "/seafhttp"
+}
+
+//打包下载parentDir下的names
+//    等待服务器打包完成后返回zip文件内容，返回的内容需要调用者关闭
+//    interval和timeout的含义同ZipTask.Wait，如需查询进度或取消，请使用CreateZipTask
+func (lib *Library) ZipDownload(parentDir string, names []string, interval, timeout time.Duration) (io.ReadCloser, error) {
+	task, err := lib.CreateZipTask(parentDir, names)
+	if err != nil {
+		return nil, fmt.Errorf("创建打包任务失败: %s", err)
+	}
+
+	_, err = task.Wait(interval, timeout)
+	if err != nil {
+		cancelErr := task.Cancel()
+		if cancelErr != nil {
+			return nil, fmt.Errorf("%s，取消打包任务失败: %s", err, cancelErr)
+		}
+		return nil, err
+	}
+
+	return task.Open()
+}